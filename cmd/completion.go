@@ -0,0 +1,64 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+//Completion writes the shell completion script of okteto to the okteto home
+func Completion() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "completion [bash|zsh|fish]",
+		Short:     "Generate the shell completion script",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := ""
+			if len(args) > 0 {
+				shell = args[0]
+			}
+
+			path, err := config.WriteCompletion(shell, func(shell string, w io.Writer) error {
+				return generateCompletion(cmd.Root(), shell, w)
+			})
+			if err != nil {
+				return err
+			}
+
+			log.Success("Completion script written to '%s'", path)
+			log.Hint("    Run 'source %s' to enable it in your current shell", path)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func generateCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(w)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	default:
+		return fmt.Errorf("completion is not supported for '%s'", shell)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+//Config shows the effective configuration of okteto
+func Config() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View the effective configuration of okteto",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := config.GetInfo()
+			if asJSON {
+				b, err := info.JSON()
+				if err != nil {
+					return fmt.Errorf("failed to generate the configuration: %s", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+
+			fmt.Printf("Version:         %s\n", info.Version)
+			fmt.Printf("OS:              %s/%s\n", info.OS, info.Arch)
+			fmt.Printf("User home:       %s\n", info.UserHome)
+			fmt.Printf("Okteto home:     %s\n", info.OktetoHome)
+			fmt.Printf("Kubeconfig:      %s\n", strings.Join(info.KubeConfig, ", "))
+			fmt.Printf("Current context: %s\n", info.CurrentContext)
+			fmt.Printf("Timeout:         %s\n", info.Timeout)
+			fmt.Printf("Read only:       %t\n", info.ReadOnly)
+			fmt.Printf("In cluster:      %t\n", info.InCluster)
+
+			names := make([]string, 0, len(info.Environment))
+			for k := range info.Environment {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			for _, k := range names {
+				fmt.Printf("%s=%s\n", k, info.Environment[k])
+			}
+
+			for _, e := range info.Errors {
+				fmt.Printf("Error: %s\n", e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "", false, "print the configuration as json")
+	return cmd
+}
@@ -15,10 +15,13 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/doctor"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/log"
@@ -31,17 +34,36 @@ func Doctor() *cobra.Command {
 	var devPath string
 	var namespace string
 	var k8sContext string
+	var check bool
+	var asJSON bool
 	cmd := &cobra.Command{
-		Use:   "doctor",
+		Use:   "doctor [service]",
 		Short: "Generates a zip file with the okteto logs",
+		Long: `Generates a zip file with the okteto logs
+
+Run
+    $ okteto doctor --check
+
+to check your configuration for common problems instead, like a home folder that isn't writable, a missing kubeconfig or an unreachable cluster.
+`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Info("starting doctor command")
 
+			if check {
+				return runChecks(asJSON)
+			}
+
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
-			dev, err := utils.LoadDev(devPath)
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			dev, err := utils.LoadDevService(devPath, name)
 			if err != nil {
 				return err
 			}
@@ -68,5 +90,24 @@ func Doctor() *cobra.Command {
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the up command was executing")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command was executing")
+	cmd.Flags().BoolVarP(&check, "check", "", false, "check the okteto configuration for common problems")
+	cmd.Flags().BoolVarP(&asJSON, "json", "", false, "print the checks as json")
 	return cmd
 }
+
+func runChecks(asJSON bool) error {
+	checks, err := doctor.Diagnose(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if err := doctor.WriteChecks(os.Stdout, checks, asJSON); err != nil {
+		return err
+	}
+
+	if config.HasFailedChecks(checks) {
+		return fmt.Errorf("some checks failed, follow the instructions above to fix them")
+	}
+
+	return nil
+}
@@ -38,11 +38,17 @@ func Down() *cobra.Command {
 	var rm bool
 
 	cmd := &cobra.Command{
-		Use:   "down",
+		Use:   "down [service]",
 		Short: "Deactivates your development container",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
-			dev, err := utils.LoadDev(devPath)
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			dev, err := utils.LoadDevService(devPath, name)
 			if err != nil {
 				return err
 			}
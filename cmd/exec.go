@@ -39,13 +39,26 @@ func Exec() *cobra.Command {
 	var k8sContext string
 
 	cmd := &cobra.Command{
-		Use:   "exec <command>",
+		Use:   "exec [service --] <command>",
 		Short: "Execute a command in your development container",
+		Long: `Execute a command in your development container
+
+Run
+    $ okteto exec api -- bash
+
+to select the development container of a manifest that defines several of them.
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			dev, err := utils.LoadDev(devPath)
+			name := ""
+			if dash := cmd.ArgsLenAtDash(); dash == 1 {
+				name = args[0]
+				args = args[1:]
+			}
+
+			dev, err := utils.LoadDevService(devPath, name)
 			if err != nil {
 				return err
 			}
@@ -66,6 +79,12 @@ func Exec() *cobra.Command {
 			if len(args) < 1 {
 				return fmt.Errorf("exec requires the COMMAND argument")
 			}
+			if dash := cmd.ArgsLenAtDash(); dash > 1 {
+				return fmt.Errorf("exec accepts a single service before '--'")
+			}
+			if dash := cmd.ArgsLenAtDash(); dash == 1 && len(args) < 2 {
+				return fmt.Errorf("exec requires the COMMAND argument")
+			}
 			return nil
 		},
 	}
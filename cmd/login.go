@@ -16,10 +16,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/login"
+	"github.com/okteto/okteto/pkg/errors"
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -44,10 +46,12 @@ By default, this will log into cloud.okteto.com. If you want to log into your Ok
     $ okteto login https://okteto.example.com
 
 to log in to a Okteto Enterprise instance running at okteto.example.com.
+
+If OKTETO_TOKEN is defined and the --token parameter isn't set, its value is used as the API token.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
-			if token == "" && k8Client.InCluster() {
+			if token == "" && login.TokenFromEnvVar() == "" && k8Client.InCluster() {
 				return fmt.Errorf("this command is not supported without the '--token' flag from inside a pod")
 			}
 
@@ -64,16 +68,19 @@ to log in to a Okteto Enterprise instance running at okteto.example.com.
 			var u *okteto.User
 			var err error
 
-			if len(token) > 0 {
+			switch {
+			case len(token) > 0:
 				log.Infof("authenticating with an api token")
 				u, err = login.WithToken(ctx, oktetoURL, token)
-			} else {
+			case login.TokenFromEnvVar() != "":
+				u, err = login.WithEnvVar(ctx, oktetoURL)
+			default:
 				u, err = login.WithBrowser(ctx, oktetoURL)
 			}
 
 			if err != nil {
 				analytics.TrackLogin(false, "", "", "", "")
-				return err
+				return withClockSkewHint(ctx, err)
 			}
 
 			log.Infof("authenticated user %s", u.ID)
@@ -95,3 +102,22 @@ to log in to a Okteto Enterprise instance running at okteto.example.com.
 	cmd.Flags().StringVarP(&token, "token", "t", "", "API token for authentication.  (optional)")
 	return cmd
 }
+
+// withClockSkewHint adds a hint to synchronize the clock to the login error err if the local clock is skewed,
+// since a skewed clock makes the tokens look invalid
+func withClockSkewHint(ctx context.Context, err error) error {
+	skew, skewErr := k8Client.CheckClockSkew(ctx)
+	if skewErr != nil {
+		log.Infof("failed to check the clock skew: %s", skewErr)
+		return err
+	}
+
+	if !k8Client.IsClockSkewed(skew) {
+		return err
+	}
+
+	return errors.UserError{
+		E:    fmt.Errorf("%s: your clock differs %s from the clock of your cluster, this can make your credentials look invalid", err, skew.Round(time.Second)),
+		Hint: k8Client.ClockSkewHint,
+	}
+}
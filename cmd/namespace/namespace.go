@@ -31,6 +31,8 @@ import (
 
 //Namespace fetch credentials for a cluster namespace
 func Namespace(ctx context.Context) *cobra.Command {
+	var standalone bool
+	var force bool
 	cmd := &cobra.Command{
 		Use:   "namespace [name]",
 		Short: "Downloads k8s credentials for a namespace",
@@ -45,16 +47,24 @@ func Namespace(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			err := RunNamespace(ctx, namespace)
+			if force && !standalone && !config.IsKubeConfigStandalone() {
+				return fmt.Errorf("the --force flag can only be used with --standalone")
+			}
+
+			err := RunNamespace(ctx, namespace, standalone, force)
 			analytics.TrackNamespace(err == nil)
 			return err
 		},
 	}
+	cmd.Flags().BoolVarP(&standalone, "standalone", "", false, "write a kubeconfig with only the okteto context instead of merging it")
+	cmd.Flags().BoolVarP(&force, "force", "", false, "overwrite the kubeconfig in standalone mode even if it has other contexts")
 	return cmd
 }
 
-//RunNamespace starts the kubeconfig sequence
-func RunNamespace(ctx context.Context, namespace string) error {
+//RunNamespace starts the kubeconfig sequence.
+//If standalone is true or OKTETO_KUBECONFIG_STANDALONE is set, the kubeconfig only has the okteto context.
+//A kubeconfig with other contexts is only overwritten if force is true
+func RunNamespace(ctx context.Context, namespace string, standalone, force bool) error {
 	if !okteto.IsAuthenticated() {
 		if !askIfLogin() {
 			return errors.ErrNotLogged
@@ -89,6 +99,16 @@ func RunNamespace(ctx context.Context, namespace string) error {
 	kubeConfigFile := config.GetKubeConfigFile()
 	clusterHost := getClusterHost()
 
+	if standalone || config.IsKubeConfigStandalone() {
+		if err := okteto.SetStandaloneKubeConfig(cred, kubeConfigFile, namespace, okteto.GetUserID(), clusterHost, force); err != nil {
+			return err
+		}
+
+		log.Success("Wrote context '%s' to '%s'", clusterHost, kubeConfigFile)
+		log.Hint("    Run 'export KUBECONFIG=%s' to use it", kubeConfigFile)
+		return nil
+	}
+
 	if err := okteto.SetKubeConfig(cred, kubeConfigFile, namespace, okteto.GetUserID(), clusterHost); err != nil {
 		return err
 	}
@@ -32,13 +32,14 @@ func Restart() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var devPath string
+	var devName string
 
 	cmd := &cobra.Command{
 		Use:   "restart",
 		Short: "Restarts the deployments listed in the services field of the okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
-			dev, err := utils.LoadDev(devPath)
+			dev, err := utils.LoadDevService(devPath, devName)
 			if err != nil {
 				return err
 			}
@@ -58,6 +59,7 @@ func Restart() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&devName, "dev", "", "", "development container of the manifest, if it defines several of them")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the restart command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the restart command is executed")
 
@@ -37,15 +37,21 @@ func Status() *cobra.Command {
 	var showInfo bool
 	var watch bool
 	cmd := &cobra.Command{
-		Use:   "status",
+		Use:   "status [service]",
 		Short: "Status of the synchronization process",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
-			dev, err := utils.LoadDev(devPath)
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			dev, err := utils.LoadDevService(devPath, name)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,121 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+)
+
+// restartDebounce is how long okteto waits for more changes before running the restart command
+const restartDebounce = 1 * time.Second
+
+// restartOnChanges runs the restart command of the manifest when the synchronization updates the files that match its paths
+func (up *upContext) restartOnChanges(ctx context.Context) {
+	changes := make(chan string)
+	go up.Sy.WatchRemoteChanges(ctx, changes)
+	debounceChanges(ctx, changes, up.Dev.Restart, restartDebounce, func() {
+		up.runRestartCommand(ctx)
+	})
+}
+
+// debounceChanges calls run once no change matching r is received for delay. It returns when ctx is done.
+// run is called on its own goroutine, so changes are drained while it runs. The changes received while run
+// is in flight are coalesced into a single call once it finishes
+func debounceChanges(ctx context.Context, changes <-chan string, r *model.Restart, delay time.Duration, run func()) {
+	var timer *time.Timer
+	var fire <-chan time.Time
+	var running chan struct{}
+	pending := false
+	start := func() {
+		running = make(chan struct{})
+		go func(done chan struct{}) {
+			defer close(done)
+			run()
+		}(running)
+	}
+
+	for {
+		select {
+		case p := <-changes:
+			if !r.Matches(p) {
+				continue
+			}
+
+			log.Infof("'%s' changed, restart scheduled", p)
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(delay)
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			if running != nil {
+				log.Infof("the restart command is still running, it will run again when it finishes")
+				pending = true
+				continue
+			}
+			start()
+		case <-running:
+			running = nil
+			if pending {
+				pending = false
+				start()
+			}
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+func (up *upContext) runRestartCommand(ctx context.Context) {
+	log.Information("Files changed, running '%s'", strings.Join(up.Dev.Restart.Command.Values, " "))
+	in := strings.NewReader("")
+	var out bytes.Buffer
+
+	var err error
+	if up.Dev.RemoteModeEnabled() {
+		err = ssh.Exec(ctx, up.Dev.Interface, up.Dev.RemotePort, false, in, &out, os.Stderr, up.Dev.Restart.Command.Values)
+	} else {
+		err = exec.Exec(
+			ctx,
+			up.Client,
+			up.RestConfig,
+			up.Dev.Namespace,
+			up.Pod,
+			up.Dev.Container,
+			false,
+			in,
+			&out,
+			os.Stderr,
+			up.Dev.Restart.Command.Values,
+		)
+	}
+
+	log.Infof("restart command output: %s", out.String())
+	if err != nil && ctx.Err() == nil {
+		log.Yellow("The restart command failed: %s", err)
+	}
+}
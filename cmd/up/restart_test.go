@@ -0,0 +1,116 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_debounceChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	runs := make(chan struct{}, 10)
+	r := &model.Restart{Paths: []string{"*.go"}}
+	done := make(chan struct{})
+	go func() {
+		debounceChanges(ctx, changes, r, 50*time.Millisecond, func() { runs <- struct{}{} })
+		close(done)
+	}()
+
+	changes <- "README.md"
+	select {
+	case <-runs:
+		t.Fatal("the restart command ran for a path that doesn't match")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	for _, p := range []string{"main.go", "pkg/server.go", "pkg/client.go"} {
+		changes <- p
+	}
+
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the restart command didn't run")
+	}
+
+	select {
+	case <-runs:
+		t.Fatal("the restart command ran more than once for a burst of changes")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("debounceChanges didn't return when the context was canceled")
+	}
+}
+
+func Test_debounceChangesSlowRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	runs := make(chan struct{}, 10)
+	release := make(chan struct{})
+	r := &model.Restart{Paths: []string{"*.go"}}
+	go debounceChanges(ctx, changes, r, 50*time.Millisecond, func() {
+		runs <- struct{}{}
+		<-release
+	})
+
+	changes <- "main.go"
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the restart command didn't run")
+	}
+
+	// the changes are drained while the restart command runs
+	for _, p := range []string{"main.go", "pkg/server.go"} {
+		select {
+		case changes <- p:
+		case <-time.After(2 * time.Second):
+			t.Fatal("the changes aren't drained while the restart command runs")
+		}
+	}
+
+	select {
+	case <-runs:
+		t.Fatal("the restart command ran while the previous run was in flight")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the restart command didn't run again for the changes received while it was running")
+	}
+	close(release)
+
+	select {
+	case <-runs:
+		t.Fatal("the restart command ran more than once for the changes received while it was running")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -15,9 +15,8 @@ package up
 
 import (
 	"fmt"
-	"io/ioutil"
-	"path/filepath"
 
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log"
 )
@@ -33,7 +32,6 @@ const (
 	synchronizing upState = "synchronizing"
 	ready         upState = "ready"
 	failed        upState = "failed"
-	stateFile             = "okteto.state"
 )
 
 func (up *upContext) updateStateFile(state upState) {
@@ -49,14 +47,50 @@ func (up *upContext) updateStateFileWithMessage(state upState, message string) {
 		log.Info("can't update state file, name is empty")
 	}
 
-	s := filepath.Join(config.GetDeploymentHome(up.Dev.Namespace, up.Dev.Name), stateFile)
-
 	m := string(state)
 	if message != "" {
 		m = fmt.Sprintf("%s:%s", m, message)
 	}
 
-	if err := ioutil.WriteFile(s, []byte(m), 0644); err != nil {
+	if err := config.WriteStateFile(up.Dev.Namespace, up.Dev.Name, []byte(m)); err != nil {
 		log.Infof("failed to update state file, %s", err)
 	}
 }
+
+func (up *upContext) saveManifestHash() {
+	if up.manifestHash == "" {
+		return
+	}
+
+	if err := config.WriteManifestHash(up.Dev.Namespace, up.Dev.Name, up.manifestHash); err != nil {
+		log.Infof("failed to save the manifest hash, %s", err)
+	}
+}
+
+// warnIfManifestChanged compares the manifest on disk with the one used to activate the development container.
+// Reconnecting keeps using the manifest loaded at startup, so the user must run 'okteto up' again to apply the changes
+func (up *upContext) warnIfManifestChanged() {
+	if up.manifestChanged || up.devPath == utils.StdinDevManifest {
+		return
+	}
+
+	saved, err := config.ReadManifestHash(up.Dev.Namespace, up.Dev.Name)
+	if err != nil {
+		log.Infof("failed to read the manifest hash, %s", err)
+		return
+	}
+
+	dev, err := utils.LoadDevService(up.devPath, up.service)
+	if err != nil {
+		log.Infof("failed to reload the manifest, %s", err)
+		return
+	}
+
+	if dev.Hash() == saved {
+		return
+	}
+
+	up.manifestChanged = true
+	log.Yellow("Your okteto manifest has changed since your development container was activated.")
+	log.Yellow("Exit and run 'okteto up' again to apply the changes.")
+}
@@ -27,6 +27,10 @@ import (
 )
 
 func checkStignoreConfiguration(dev *model.Dev) error {
+	if dev.Sync.HasIgnores() {
+		return writeManifestStignores(dev)
+	}
+
 	for _, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
 		gitPath := filepath.Join(folder.LocalPath, ".git")
@@ -102,3 +106,34 @@ func askIfUpdatingStignore(folder, stignorePath, gitPath string) error {
 	}
 	return nil
 }
+
+// writeManifestStignores generates the '.stignore' files of the synchronized folders from the 'sync' section of the manifest.
+// '.stignore' files that weren't generated by okteto are left untouched
+func writeManifestStignores(dev *model.Dev) error {
+	for _, folder := range dev.Sync.Folders {
+		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
+		if model.FileExists(stignorePath) {
+			current, err := ioutil.ReadFile(stignorePath)
+			if err != nil {
+				return fmt.Errorf("failed to read '%s': %s", stignorePath, err.Error())
+			}
+
+			if len(strings.TrimSpace(string(current))) > 0 && !model.IsGeneratedStignore(current) {
+				log.Yellow("'%s' wasn't generated by okteto, 'sync.ignore' is not applied to '%s'. Remove it to use the patterns of your manifest", stignorePath, folder.LocalPath)
+				continue
+			}
+		}
+
+		c, err := dev.Sync.Stignore(folder.LocalPath)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("writing '%s' from the manifest", stignorePath)
+		if err := ioutil.WriteFile(stignorePath, c, 0644); err != nil {
+			return fmt.Errorf("failed to write '%s': %s", stignorePath, err.Error())
+		}
+	}
+
+	return nil
+}
@@ -28,6 +28,10 @@ type upContext struct {
 	Cancel            context.CancelFunc
 	ShutdownCompleted chan bool
 	Dev               *model.Dev
+	devPath           string
+	service           string
+	manifestHash      string
+	manifestChanged   bool
 	isOktetoNamespace bool
 	isSwap            bool
 	isRetry           bool
@@ -33,6 +33,7 @@ import (
 	"github.com/okteto/okteto/pkg/errors"
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/dryrun"
 	"github.com/okteto/okteto/pkg/k8s/exec"
 	okLabels "github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/k8s/namespaces"
@@ -51,11 +52,15 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 // ReconnectingMessage is the message shown when we are trying to reconnect
 const ReconnectingMessage = "Trying to reconnect to your cluster. File synchronization will automatically resume when the connection improves."
 
+// devContainerLogsTimeout is the time the logs of the development container are followed when it fails to start
+const devContainerLogsTimeout = 5 * time.Second
+
 var (
 	localClusters = []string{"127.", "172.", "192.", "169.", model.Localhost, "::1", "fe80::", "fc00::"}
 )
@@ -70,21 +75,31 @@ func Up() *cobra.Command {
 	var build bool
 	var forcePull bool
 	var resetSyncthing bool
+	var dryRun bool
 	cmd := &cobra.Command{
-		Use:   "up",
+		Use:   "up [service]",
 		Short: "Activates your development container",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
 
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
+			if dryRun {
+				return printDryRun(devPath, name, namespace, k8sContext)
+			}
+
 			u := upgradeAvailable()
 			if len(u) > 0 {
 				warningFolder := filepath.Join(config.GetOktetoHome(), ".warnings")
 				if utils.GetWarningState(warningFolder, "version") != u {
 					log.Yellow("Okteto %s is available. To upgrade:", u)
-					log.Yellow("    %s", getUpgradeCommand())
+					log.Yellow("    %s", config.GetUpgradeCommand())
 					if err := utils.SetWarningState(warningFolder, "version", u); err != nil {
 						log.Infof("failed to set warning version state: %s", err.Error())
 					}
@@ -109,10 +124,11 @@ func Up() *cobra.Command {
 
 			checkLocalWatchesConfiguration()
 
-			dev, err := loadDevOrInit(namespace, k8sContext, devPath)
+			dev, err := loadDevOrInit(namespace, k8sContext, devPath, name)
 			if err != nil {
 				return err
 			}
+			manifestHash := dev.Hash()
 
 			if err := loadDevOverrides(dev, namespace, k8sContext, forcePull, remote); err != nil {
 				return err
@@ -128,6 +144,9 @@ func Up() *cobra.Command {
 
 			up := &upContext{
 				Dev:            dev,
+				devPath:        devPath,
+				service:        name,
+				manifestHash:   manifestHash,
 				Exit:           make(chan error, 1),
 				resetSyncthing: resetSyncthing,
 			}
@@ -154,11 +173,39 @@ func Up() *cobra.Command {
 	cmd.Flags().BoolVarP(&build, "build", "", false, "build on-the-fly the dev image using the info provided by the 'build' okteto manifest field")
 	cmd.Flags().BoolVarP(&forcePull, "pull", "", false, "force dev image pull")
 	cmd.Flags().BoolVarP(&resetSyncthing, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "print the kubernetes manifests of your development container without applying them")
 	return cmd
 }
 
-func loadDevOrInit(namespace, k8sContext, devPath string) (*model.Dev, error) {
-	dev, err := utils.LoadDev(devPath)
+func printDryRun(devPath, name, namespace, k8sContext string) error {
+	dev, err := utils.LoadDevService(devPath, name)
+	if err != nil {
+		return err
+	}
+
+	dev.LoadContext(namespace, k8sContext)
+
+	c, _, currentNamespace, err := k8Client.GetLocal(dev.Context)
+	if err != nil {
+		log.Infof("failed to load local Kubeconfig: %s", err)
+		return fmt.Errorf("failed to load your local Kubeconfig: %q context not found in %q", dev.Context, config.GetKubeConfigFile())
+	}
+
+	if dev.Namespace == "" {
+		dev.Namespace = currentNamespace
+	}
+
+	manifests, err := dryrun.Translate(context.Background(), dev, c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(manifests))
+	return nil
+}
+
+func loadDevOrInit(namespace, k8sContext, devPath, name string) (*model.Dev, error) {
+	dev, err := utils.LoadDevService(devPath, name)
 
 	if err == nil {
 		return dev, nil
@@ -179,7 +226,7 @@ func loadDevOrInit(namespace, k8sContext, devPath string) (*model.Dev, error) {
 	}
 
 	log.Success(fmt.Sprintf("okteto manifest (%s) created", devPath))
-	return utils.LoadDev(devPath)
+	return utils.LoadDevService(devPath, name)
 }
 
 func loadDevOverrides(dev *model.Dev, namespace, k8sContext string, forcePull bool, remote int) error {
@@ -207,6 +254,14 @@ func loadDevOverrides(dev *model.Dev, namespace, k8sContext string, forcePull bo
 
 func (up *upContext) start(autoDeploy, build bool) error {
 
+	ctx := context.Background()
+	if _, err := config.LoadKubeConfig(ctx); err != nil {
+		if _, ok := err.(*config.KubeConfigTimeoutError); ok {
+			return err
+		}
+		log.Infof("failed to preload the kubeconfig: %s", err)
+	}
+
 	var namespace string
 	var err error
 	up.Client, up.RestConfig, namespace, err = k8Client.GetLocal(up.Dev.Context)
@@ -220,8 +275,12 @@ func (up *upContext) start(autoDeploy, build bool) error {
 		up.Dev.Namespace = namespace
 	}
 
-	ctx := context.Background()
-	ns, err := namespaces.Get(ctx, up.Dev.Namespace, up.Client)
+	var ns *apiv1.Namespace
+	err = config.RetryWithTimeout(ctx, "namespace", func(ctx context.Context) error {
+		var err error
+		ns, err = namespaces.Get(ctx, up.Dev.Namespace, up.Client)
+		return err
+	})
 	if err != nil {
 		log.Infof("failed to get namespace %s: %s", up.Dev.Namespace, err)
 		return fmt.Errorf("couldn't get namespace/%s, please try again", up.Dev.Namespace)
@@ -233,6 +292,13 @@ func (up *upContext) start(autoDeploy, build bool) error {
 
 	up.isOktetoNamespace = namespaces.IsOktetoNamespace(ns)
 
+	release, err := config.AcquireDeploymentLock(up.Dev.Namespace, up.Dev.Name)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	if err := createPIDFile(up.Dev.Namespace, up.Dev.Name); err != nil {
 		log.Infof("failed to create pid file for %s - %s: %s", up.Dev.Namespace, up.Dev.Name, err)
 		return fmt.Errorf("couldn't create pid file for %s - %s", up.Dev.Namespace, up.Dev.Name)
@@ -323,11 +389,22 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		return err
 	}
 
+	if !up.isRetry {
+		permissions := namespaces.GetRequiredPermissions(up.Dev, create)
+		if err := namespaces.CheckPermissions(ctx, up.Dev.Namespace, permissions, up.Client); err != nil {
+			return err
+		}
+	}
+
 	if up.isRetry && !deployments.IsDevModeOn(d) {
 		log.Information("Development container has been deactivated")
 		return nil
 	}
 
+	if up.isRetry {
+		up.warnIfManifestChanged()
+	}
+
 	if deployments.IsDevModeOn(d) && deployments.HasBeenChanged(d) {
 		return errors.UserError{
 			E: fmt.Errorf("Deployment '%s' has been modified while your development container was active", d.Name),
@@ -339,7 +416,10 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		}
 	}
 
-	if _, err := registry.GetImageTagWithDigest(ctx, up.Dev.Namespace, up.Dev.Image.Name); err == errors.ErrNotFound {
+	if up.Dev.Build != nil && up.Dev.Image.Name == "" {
+		log.Infof("'image' is not defined, building the dev image from the 'build' section")
+		build = true
+	} else if _, err := registry.GetImageTagWithDigest(ctx, up.Dev.Namespace, up.Dev.Image.Name); err == errors.ErrNotFound {
 		log.Infof("image '%s' not found, building it: %s", up.Dev.Image.Name, err.Error())
 		build = true
 	}
@@ -363,10 +443,14 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 	}
 
 	log.Success("Development container activated")
+	if !up.isRetry {
+		up.saveManifestHash()
+	}
 	up.isRetry = true
 
 	if err := up.forwards(ctx); err != nil {
 		if err == errors.ErrSSHConnectError {
+			up.printDevContainerLogs(ctx)
 			err := up.checkOktetoStartError(ctx, "Failed to connect to your development container")
 			if err == errors.ErrLostSyncthing {
 				if err := pods.Destroy(ctx, up.Pod, up.Dev.Namespace, up.Client); err != nil {
@@ -397,6 +481,10 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 	}
 	log.Success("Files synchronized")
 
+	if up.Dev.Restart != nil {
+		go up.restartOnChanges(ctx)
+	}
+
 	go func() {
 		output := <-up.cleaned
 		log.Debugf("clean command output: %s", output)
@@ -514,11 +602,11 @@ func (up *upContext) buildDevImage(ctx context.Context, d *appsv1.Deployment, cr
 		}
 	}
 
-	if oktetoRegistryURL == "" && create && up.Dev.Image.Name == "" {
+	if up.Dev.Build == nil && oktetoRegistryURL == "" && create && up.Dev.Image.Name == "" {
 		return fmt.Errorf("no value for 'Image' has been provided in your okteto manifest")
 	}
 
-	if up.Dev.Image.Name == "" {
+	if up.Dev.Build == nil && up.Dev.Image.Name == "" {
 		devContainer := deployments.GetDevContainer(&d.Spec.Template.Spec, up.Dev.Container)
 		if devContainer == nil {
 			return fmt.Errorf("container '%s' does not exist in deployment '%s'", up.Dev.Container, up.Dev.Name)
@@ -532,7 +620,7 @@ func (up *upContext) buildDevImage(ctx context.Context, d *appsv1.Deployment, cr
 	}
 	log.Information("Running your build in %s...", buildKitHost)
 
-	imageTag := registry.GetImageTag(up.Dev.Image.Name, up.Dev.Name, up.Dev.Namespace, oktetoRegistryURL)
+	imageTag := getDevImageTag(up.Dev, oktetoRegistryURL)
 	log.Infof("building dev image tag %s", imageTag)
 
 	buildArgs := model.SerializeBuildArgs(up.Dev.Image.Args)
@@ -540,16 +628,34 @@ func (up *upContext) buildDevImage(ctx context.Context, d *appsv1.Deployment, cr
 		return fmt.Errorf("error building dev image '%s': %s", imageTag, err)
 	}
 	for _, s := range up.Dev.Services {
-		if s.Image.Name == up.Dev.Image.Name {
+		if up.Dev.Image.Name != "" && s.Image.Name == up.Dev.Image.Name {
 			s.Image.Name = imageTag
 			s.SetLastBuiltAnnotation()
 		}
 	}
 	up.Dev.Image.Name = imageTag
+	if up.Dev.Build != nil {
+		// the dev container runs the image built from 'build' instead of the image of the deployment
+		up.Dev.EmptyImage = false
+	}
 	up.Dev.SetLastBuiltAnnotation()
 	return nil
 }
 
+// getDevImageTag returns the tag of the dev image to build.
+// With a 'build' section, the image is tagged as 'image', or with a tag derived from the name of the development container if 'image' is empty
+func getDevImageTag(dev *model.Dev, oktetoRegistryURL string) string {
+	if dev.Build == nil || (dev.Image.Name == "" && oktetoRegistryURL != "") {
+		return registry.GetImageTag(dev.Image.Name, dev.Name, dev.Namespace, oktetoRegistryURL)
+	}
+
+	if dev.Image.Name == "" {
+		return registry.GetImageTag(dev.Name, dev.Name, dev.Namespace, oktetoRegistryURL)
+	}
+
+	return dev.Image.Name
+}
+
 func (up *upContext) setDevContainer(d *appsv1.Deployment) error {
 	devContainer := deployments.GetDevContainer(&d.Spec.Template.Spec, up.Dev.Container)
 	if devContainer == nil {
@@ -720,6 +826,10 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 }
 
 func (up *upContext) initializeSyncthing() error {
+	if err := config.CheckDiskSpace(config.MinDiskSpace); err != nil {
+		return err
+	}
+
 	sy, err := syncthing.New(up.Dev)
 	if err != nil {
 		return err
@@ -914,6 +1024,18 @@ func (up *upContext) runCommand(ctx context.Context) error {
 	)
 }
 
+// printDevContainerLogs shows the logs of the development container, and of its previous instance if it is crash looping
+func (up *upContext) printDevContainerLogs(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, devContainerLogsTimeout)
+	defer cancel()
+
+	log.Information("Logs of your development container:")
+	prefix := fmt.Sprintf("[%s]", up.Dev.Container)
+	if err := pods.StreamLogs(ctx, up.Dev.Namespace, up.Pod, up.Dev.Container, prefix, up.Client); err != nil && err != context.DeadlineExceeded {
+		log.Infof("failed to get the logs of the development container: %s", err)
+	}
+}
+
 func (up *upContext) checkOktetoStartError(ctx context.Context, msg string) error {
 	userID := pods.GetDevPodUserID(ctx, up.Dev, up.Client)
 	if up.Dev.PersistentVolumeEnabled() {
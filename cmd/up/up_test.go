@@ -101,3 +101,43 @@ func Test_printDisplayContext(t *testing.T) {
 	}
 
 }
+
+func Test_getDevImageTag(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dev      *model.Dev
+		registry string
+		expected string
+	}{
+		{
+			name:     "image",
+			dev:      &model.Dev{Name: "api", Namespace: "ns", Image: &model.BuildInfo{Name: "okteto/api:1.0"}},
+			expected: "okteto/api:okteto",
+		},
+		{
+			name:     "build-with-image",
+			dev:      &model.Dev{Name: "api", Namespace: "ns", Image: &model.BuildInfo{Name: "registry.example.com/api:dev"}, Build: &model.BuildInfo{Context: "."}},
+			registry: "registry.okteto.net",
+			expected: "registry.example.com/api:dev",
+		},
+		{
+			name:     "build-without-image",
+			dev:      &model.Dev{Name: "api", Namespace: "ns", Image: &model.BuildInfo{}, Build: &model.BuildInfo{Context: "."}},
+			expected: "api:okteto",
+		},
+		{
+			name:     "build-without-image-okteto-registry",
+			dev:      &model.Dev{Name: "api", Namespace: "ns", Image: &model.BuildInfo{}, Build: &model.BuildInfo{Context: "."}},
+			registry: "registry.okteto.net",
+			expected: "registry.okteto.net/ns/api:okteto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getDevImageTag(tt.dev, tt.registry); got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
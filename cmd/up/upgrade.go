@@ -16,7 +16,6 @@ package up
 import (
 	"context"
 	"fmt"
-	"runtime"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/github"
@@ -25,7 +24,7 @@ import (
 )
 
 func upgradeAvailable() string {
-	current, err := semver.NewVersion(config.VersionString)
+	current, err := config.GetVersion()
 	if err != nil {
 		return ""
 	}
@@ -54,7 +53,7 @@ func upgradeAvailable() string {
 
 // GetLatestVersionFromGithub returns the latest okteto version from Github
 func GetLatestVersionFromGithub() (string, error) {
-	client := github.NewClient(nil)
+	client := github.NewClient(config.NewHTTPClient())
 	ctx := context.Background()
 	releases, _, err := client.Repositories.ListReleases(ctx, "okteto", "okteto", &github.ListOptions{PerPage: 5})
 	if err != nil {
@@ -86,11 +85,3 @@ func shouldNotify(latest, current *semver.Version) bool {
 
 	return false
 }
-
-func getUpgradeCommand() string {
-	if runtime.GOOS == "windows" {
-		return `https://github.com/okteto/okteto/releases/latest/download/okteto.exe`
-	}
-
-	return `curl https://get.okteto.com -sSfL | sh`
-}
@@ -18,7 +18,9 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
@@ -28,21 +30,47 @@ const (
 	//DefaultDevManifest default okteto manifest file
 	DefaultDevManifest   = "okteto.yml"
 	secondaryDevManifest = "okteto.yaml"
+
+	//StdinDevManifest reads the okteto manifest from stdin
+	StdinDevManifest = "-"
 )
 
 //LoadDev loads an okteto manifest checking "yml" and "yaml"
 func LoadDev(devPath string) (*model.Dev, error) {
+	return LoadDevService(devPath, "")
+}
+
+//LoadDevService loads the development container called name from an okteto manifest checking "yml" and "yaml".
+//The timeout of the manifest, if any, is applied as the base timeout
+func LoadDevService(devPath, name string) (*model.Dev, error) {
+	dev, err := loadDevService(devPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	config.SetManifestTimeout(time.Duration(dev.Timeout))
+	return dev, nil
+}
+
+func loadDevService(devPath, name string) (*model.Dev, error) {
+	if devPath == StdinDevManifest {
+		if name != "" {
+			return nil, fmt.Errorf("selecting a development container isn't supported when reading the manifest from stdin")
+		}
+		return model.Read(os.Stdin)
+	}
+
 	if !model.FileExists(devPath) {
 		if devPath == DefaultDevManifest {
 			if model.FileExists(secondaryDevManifest) {
-				return LoadDev(secondaryDevManifest)
+				return loadDevService(secondaryDevManifest, name)
 			}
 		}
 
 		return nil, fmt.Errorf("'%s' does not exist. Generate it by executing 'okteto init'", devPath)
 	}
 
-	return model.Get(devPath)
+	return model.GetService(devPath, name)
 }
 
 //LoadDevOrDefault loads an okteto manifest or a default one if does not exist
@@ -53,7 +81,7 @@ func LoadDevOrDefault(devPath, name string) (*model.Dev, error) {
 	}
 
 	if errors.IsNotExist(err) && len(name) > 0 {
-		dev, err := model.Read(nil)
+		dev, err := model.Parse(nil)
 		if err != nil {
 			return nil, err
 		}
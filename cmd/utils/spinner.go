@@ -15,8 +15,6 @@ package utils
 
 import (
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -34,7 +32,7 @@ type Spinner struct {
 
 //NewSpinner returns a new Spinner
 func NewSpinner(suffix string) *Spinner {
-	spinnerSupport = !loadBoolean("OKTETO_DISABLE_SPINNER")
+	spinnerSupport = log.IsInteractive()
 	s := sp.New(sp.CharSets[14], 100*time.Millisecond)
 	s.HideCursor = true
 	s.Suffix = fmt.Sprintf(" %s", suffix)
@@ -43,20 +41,6 @@ func NewSpinner(suffix string) *Spinner {
 	}
 }
 
-func loadBoolean(k string) bool {
-	v := os.Getenv(k)
-	if v == "" {
-		v = "false"
-	}
-
-	h, err := strconv.ParseBool(v)
-	if err != nil {
-		log.Yellow("'%s' is not a valid value for environment variable %s", v, k)
-	}
-
-	return h
-}
-
 //Start starts the spinner
 func (p *Spinner) Start() {
 	if spinnerSupport {
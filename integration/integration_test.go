@@ -24,7 +24,6 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -574,7 +573,7 @@ func waitForUpExit(wg *sync.WaitGroup) error {
 func waitForReady(namespace, name string) error {
 	log.Println("waiting for okteto up to be ready")
 
-	state := path.Join(config.GetOktetoHome(), namespace, name, "okteto.state")
+	state := config.GetStateFile(namespace, name)
 
 	t := time.NewTicker(1 * time.Second)
 	for i := 0; i < 360; i++ {
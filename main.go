@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/cmd"
 	initCMD "github.com/okteto/okteto/cmd/init"
@@ -59,10 +60,33 @@ func init() {
 	}
 }
 
+// initFiles initializes the log file and the defaults manifest.
+// They are skipped if the okteto folder can't be created, e.g. in read-only mode
+func initFiles() {
+	logFile, logErr := config.GetLogFileE()
+	log.Init(logrus.WarnLevel, logFile, config.VersionString)
+	if logErr != nil {
+		log.Infof("the log file is disabled: %s", logErr)
+	}
+
+	defaults, err := config.GetDefaultsManifestFileE()
+	if err != nil {
+		log.Infof("the defaults manifest is disabled: %s", err)
+	}
+	model.DefaultsManifestPath = defaults
+}
+
 func main() {
 	ctx := context.Background()
-	log.Init(logrus.WarnLevel, config.GetOktetoHome(), config.VersionString)
+	initFiles()
+	removeKubeConfig, err := config.LoadKubeConfigContents()
+	if err != nil {
+		log.Fail(err.Error())
+		os.Exit(1)
+	}
+
 	var logLevel string
+	var timeout time.Duration
 
 	root := &cobra.Command{
 		Use:           fmt.Sprintf("%s COMMAND [ARG...]", config.GetBinaryName()),
@@ -71,6 +95,13 @@ func main() {
 		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
 			ccmd.SilenceUsage = true
 			log.SetLevel(logLevel)
+			// commands like 'pipeline deploy' define their own --timeout, that shadows the global one
+			if ccmd.Root().PersistentFlags().Changed("timeout") {
+				config.SetTimeout(timeout)
+			}
+			if err := config.CleanTempDir(); err != nil {
+				log.Infof("failed to clean the temp folder: %s", err)
+			}
 			log.Infof("started %s", strings.Join(os.Args, " "))
 
 		},
@@ -79,9 +110,12 @@ func main() {
 		},
 	}
 
-	root.PersistentFlags().StringVarP(&logLevel, "loglevel", "l", "warn", "amount of information outputted (debug, info, warn, error)")
+	root.PersistentFlags().StringVarP(&logLevel, "loglevel", "l", log.GetDefaultLevel("warn"), "amount of information outputted (debug, info, warn, error)")
+	root.PersistentFlags().DurationVar(&timeout, "timeout", 0, "timeout of each action (e.g. 2m), it overrides OKTETO_TIMEOUT")
 	root.AddCommand(cmd.Analytics())
+	root.AddCommand(cmd.Completion())
 	root.AddCommand(cmd.Version())
+	root.AddCommand(cmd.Config())
 	root.AddCommand(cmd.Login())
 	root.AddCommand(cmd.Build(ctx))
 	root.AddCommand(cmd.Create(ctx))
@@ -98,7 +132,8 @@ func main() {
 	root.AddCommand(cmd.Exec())
 	root.AddCommand(cmd.Restart())
 
-	err := root.Execute()
+	err = root.Execute()
+	removeKubeConfig()
 
 	if err != nil {
 		log.Fail(err.Error())
@@ -0,0 +1,60 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_initFilesReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_HOME")
+		os.Unsetenv("OKTETO_READONLY")
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+		config.ResetUserHomeDir()
+		model.DefaultsManifestPath = ""
+	}()
+
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Unsetenv("OKTETO_FOLDER")
+	os.Unsetenv("OKTETO_LOG_FILE")
+	os.Setenv("OKTETO_HOME", dir)
+	os.Setenv("OKTETO_READONLY", "1")
+	config.ResetUserHomeDir()
+
+	model.DefaultsManifestPath = "defaults.yml"
+	initFiles()
+
+	if model.DefaultsManifestPath != "" {
+		t.Errorf("the defaults manifest was set in read-only mode: %s", model.DefaultsManifestPath)
+	}
+
+	if model.FileExists(filepath.Join(dir, ".okteto")) {
+		t.Error("the okteto folder was created in read-only mode")
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"path/filepath"
 	"runtime"
 	"time"
 
@@ -60,14 +59,15 @@ var (
 )
 
 func init() {
+	t := config.NewHTTPTransport()
+	t.DialContext = (&net.Dialer{
+		Timeout: 5 * time.Second,
+	}).DialContext
+	t.TLSHandshakeTimeout = 5 * time.Second
+
 	c := &http.Client{
-		Timeout: time.Second * 5,
-		Transport: &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout: 5 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout: 5 * time.Second,
-		},
+		Timeout:   time.Second * 5,
+		Transport: config.WithUserAgent(t),
 	}
 
 	mixpanelClient = mixpanel.NewFromClient(c, mixpanelToken, "")
@@ -188,7 +188,7 @@ func TrackDestroyStack(success bool) {
 
 // TrackLogin sends a tracking event to mixpanel when the user logs in
 func TrackLogin(success bool, name, email, oktetoID, externalID string) {
-	if !isEnabled() {
+	if !config.IsAnalyticsEnabled() {
 		return
 	}
 
@@ -212,6 +212,10 @@ func TrackLogin(success bool, name, email, oktetoID, externalID string) {
 
 // TrackSignup sends a tracking event to mixpanel when the user signs up
 func TrackSignup(success bool, userID string) {
+	if !config.IsAnalyticsEnabled() {
+		return
+	}
+
 	if err := mixpanelClient.Alias(getMachineID(), userID); err != nil {
 		log.Errorf("failed to alias %s to %s", getMachineID(), userID)
 	}
@@ -220,9 +224,10 @@ func TrackSignup(success bool, userID string) {
 }
 
 func track(event string, success bool, props map[string]interface{}) {
-	if !isEnabled() {
+	if !config.IsAnalyticsEnabled() {
 		return
 	}
+
 	mpOS := ""
 	switch runtime.GOOS {
 	case "darwin":
@@ -255,42 +260,20 @@ func track(event string, success bool, props map[string]interface{}) {
 	}
 }
 
-func getFlagPath() string {
-	return filepath.Join(config.GetOktetoHome(), ".noanalytics")
-}
-
 // Disable disables analytics
 func Disable(version string) error {
-	var _, err = os.Stat(getFlagPath())
 	trackDisable(true)
-	if os.IsNotExist(err) {
-		var file, err = os.Create(getFlagPath())
-		if err != nil {
-			trackDisable(false)
-			return err
-		}
-
-		defer file.Close()
+	if err := config.SetAnalyticsEnabled(false); err != nil {
+		trackDisable(false)
+		return err
 	}
+
 	return nil
 }
 
 // Enable enables analytics
 func Enable(version string) error {
-	var _, err = os.Stat(getFlagPath())
-	if os.IsNotExist(err) {
-		return nil
-	}
-
-	return os.Remove(getFlagPath())
-}
-
-func isEnabled() bool {
-	if _, err := os.Stat(getFlagPath()); !os.IsNotExist(err) {
-		return false
-	}
-
-	return true
+	return config.SetAnalyticsEnabled(true)
 }
 
 func getTrackID() string {
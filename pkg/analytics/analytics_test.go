@@ -15,9 +15,14 @@ package analytics
 
 import (
 	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/dukex/mixpanel"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/okteto"
 )
 
@@ -52,9 +57,14 @@ func Test_getTrackID(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			defer os.RemoveAll(dir)
+			defer func() {
+				os.RemoveAll(dir)
+				os.Unsetenv("OKTETO_HOME")
+				config.ResetUserHomeDir()
+			}()
 
 			os.Setenv("OKTETO_HOME", dir)
+			config.ResetUserHomeDir()
 
 			if len(tt.machineID) > 0 {
 				if err := okteto.SaveMachineID(tt.machineID); err != nil {
@@ -89,3 +99,82 @@ func Test_getTrackID(t *testing.T) {
 		})
 	}
 }
+
+type countingTransport struct {
+	mu       sync.Mutex
+	requests int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.requests++
+	c.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("1")),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+func (c *countingTransport) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests
+}
+
+func trackAll() {
+	TrackInit(true, "golang")
+	TrackNamespace(true)
+	TrackUp(true, "dev", "remote", true, true, false, false)
+	TrackDown(true)
+	TrackLogin(true, "cindy", "cindy@okteto.com", "id", "cindy")
+	TrackSignup(true, "id")
+}
+
+func TestTrackDisabled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := mixpanelClient
+	defer func() {
+		mixpanelClient = original
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_HOME")
+		os.Unsetenv("OKTETO_DISABLE_ANALYTICS")
+		config.ResetUserHomeDir()
+	}()
+
+	os.Setenv("OKTETO_HOME", dir)
+	config.ResetUserHomeDir()
+	transport := &countingTransport{}
+	mixpanelClient = mixpanel.NewFromClient(&http.Client{Transport: transport}, mixpanelToken, "")
+
+	os.Setenv("OKTETO_DISABLE_ANALYTICS", "1")
+	trackAll()
+	if transport.count() != 0 {
+		t.Fatalf("%d requests sent with OKTETO_DISABLE_ANALYTICS set", transport.count())
+	}
+
+	os.Unsetenv("OKTETO_DISABLE_ANALYTICS")
+	if err := Disable(config.VersionString); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := transport.count()
+	trackAll()
+	if transport.count() != sent {
+		t.Fatalf("%d requests sent with the analytics disabled", transport.count()-sent)
+	}
+
+	if err := Enable(config.VersionString); err != nil {
+		t.Fatal(err)
+	}
+
+	trackAll()
+	if transport.count() == sent {
+		t.Fatal("no requests sent with the analytics enabled")
+	}
+}
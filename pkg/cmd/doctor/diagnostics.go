@@ -0,0 +1,117 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	k8Client "github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+// Diagnose runs the checks of config.RunDiagnostics, and checks that the cluster is reachable,
+// that the local clock is in sync with it and that the syncthing binary is installed
+func Diagnose(ctx context.Context) ([]config.Check, error) {
+	checks, err := config.RunDiagnostics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks = append(checks, checkCluster(k8Client.CheckClockSkew(ctx))...)
+	checks = append(checks, checkSyncthing(syncthing.IsInstalled()))
+	return checks, ctx.Err()
+}
+
+func checkCluster(skew time.Duration, err error) []config.Check {
+	if err != nil {
+		return []config.Check{
+			{
+				Name:        "cluster",
+				Status:      config.CheckFail,
+				Message:     err.Error(),
+				Remediation: "Check your network connection and that the current context of your kubeconfig points to a running cluster",
+			},
+		}
+	}
+
+	checks := []config.Check{
+		{
+			Name:    "cluster",
+			Status:  config.CheckPass,
+			Message: "the API server is reachable",
+		},
+	}
+
+	clock := config.Check{
+		Name:    "clock",
+		Status:  config.CheckPass,
+		Message: fmt.Sprintf("your clock differs %s from the clock of your cluster", skew.Round(time.Second)),
+	}
+	if k8Client.IsClockSkewed(skew) {
+		clock.Status = config.CheckWarn
+		clock.Remediation = "Synchronize your clock (e.g. enable automatic date and time in your system settings)"
+	}
+
+	return append(checks, clock)
+}
+
+func checkSyncthing(installed bool) config.Check {
+	if !installed {
+		return config.Check{
+			Name:        "syncthing",
+			Status:      config.CheckWarn,
+			Message:     "the syncthing binary is not installed",
+			Remediation: "Run 'okteto up' to install it, or check your network connection if the installation fails",
+		}
+	}
+
+	return config.Check{
+		Name:    "syncthing",
+		Status:  config.CheckPass,
+		Message: "the syncthing binary is installed",
+	}
+}
+
+// WriteChecks writes the checks to w, as text or as json
+func WriteChecks(w io.Writer, checks []config.Check, asJSON bool) error {
+	if asJSON {
+		b, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate the checks: %s", err)
+		}
+
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	for _, c := range checks {
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", strings.ToUpper(string(c.Status)), c.Name, c.Message); err != nil {
+			return err
+		}
+
+		if c.Remediation != "" && c.Status != config.CheckPass {
+			if _, err := fmt.Fprintf(w, "       %s\n", c.Remediation); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+)
+
+func Test_checkCluster(t *testing.T) {
+	var tests = []struct {
+		name     string
+		skew     time.Duration
+		err      error
+		expected []config.CheckStatus
+	}{
+		{name: "unreachable", err: fmt.Errorf("connection refused"), expected: []config.CheckStatus{config.CheckFail}},
+		{name: "in-sync", skew: time.Second, expected: []config.CheckStatus{config.CheckPass, config.CheckPass}},
+		{name: "behind", skew: time.Minute, expected: []config.CheckStatus{config.CheckPass, config.CheckWarn}},
+		{name: "ahead", skew: -time.Minute, expected: []config.CheckStatus{config.CheckPass, config.CheckWarn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := checkCluster(tt.skew, tt.err)
+			result := []config.CheckStatus{}
+			for _, c := range checks {
+				result = append(result, c.Status)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %+v", tt.expected, checks)
+			}
+		})
+	}
+}
+
+func Test_checkSyncthing(t *testing.T) {
+	if c := checkSyncthing(true); c.Status != config.CheckPass {
+		t.Errorf("expected pass, got %+v", c)
+	}
+
+	if c := checkSyncthing(false); c.Status != config.CheckWarn {
+		t.Errorf("expected warn, got %+v", c)
+	}
+}
+
+func TestWriteChecks(t *testing.T) {
+	checks := []config.Check{
+		{Name: "timeout", Status: config.CheckPass, Message: "the timeout is 30s"},
+		{Name: "kubeconfig", Status: config.CheckFail, Message: "kubeconfig not found", Remediation: "Run 'okteto namespace'"},
+	}
+
+	var text bytes.Buffer
+	if err := WriteChecks(&text, checks, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[PASS] timeout: the timeout is 30s\n[FAIL] kubeconfig: kubeconfig not found\n       Run 'okteto namespace'\n"
+	if text.String() != expected {
+		t.Errorf("expected %q, got %q", expected, text.String())
+	}
+
+	var out bytes.Buffer
+	if err := WriteChecks(&out, checks, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []config.Check
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(result, checks) {
+		t.Errorf("expected %+v, got %+v", checks, result)
+	}
+}
@@ -87,8 +87,8 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 	archiveName := fmt.Sprintf("okteto-doctor-%s.zip", now.Format("20060102150405"))
 	files := []string{summaryFilename}
 	files = append(files, stignoreFilenames...)
-	if model.FileExists(filepath.Join(config.GetOktetoHome(), "okteto.log")) {
-		files = append(files, filepath.Join(config.GetOktetoHome(), "okteto.log"))
+	if model.FileExists(config.GetLogFile()) {
+		files = append(files, config.GetLogFile())
 	}
 	if model.FileExists(syncthing.GetLogFile(dev.Namespace, dev.Name)) {
 		files = append(files, syncthing.GetLogFile(dev.Namespace, dev.Name))
@@ -18,17 +18,23 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/skratchdot/open-golang/open"
 )
 
+// tokenEnvVar is the env var with the API token used to log in without the browser, e.g. in CI
+const tokenEnvVar = "OKTETO_TOKEN"
+
 // WithEnvVarIfAvailable authenticates the user with OKTETO_TOKEN value
 func WithEnvVarIfAvailable(ctx context.Context) error {
-	oktetoToken := os.Getenv("OKTETO_TOKEN")
+	oktetoToken := TokenFromEnvVar()
 	if oktetoToken == "" {
 		return nil
 	}
@@ -41,17 +47,52 @@ func WithEnvVarIfAvailable(ctx context.Context) error {
 	if oktetoURL == "" {
 		oktetoURL = okteto.CloudURL
 	}
-	if _, err := WithToken(ctx, oktetoURL, oktetoToken); err != nil {
-		return fmt.Errorf("error executing auto-login with 'OKTETO_TOKEN': %s", err)
+
+	if _, err := WithEnvVar(ctx, oktetoURL); err != nil {
+		return errors.UserError{
+			E:    fmt.Errorf("error executing auto-login with '%s': %s", tokenEnvVar, err),
+			Hint: fmt.Sprintf("Check that the value of '%s' is a valid API token for %s", tokenEnvVar, oktetoURL),
+		}
 	}
 	return nil
 }
 
+// TokenFromEnvVar returns the API token defined in OKTETO_TOKEN, or an empty string if it isn't defined
+func TokenFromEnvVar() string {
+	return strings.TrimSpace(os.Getenv(tokenEnvVar))
+}
+
+// WithEnvVar authenticates the user with the API token defined in OKTETO_TOKEN
+func WithEnvVar(ctx context.Context, oktetoURL string) (*okteto.User, error) {
+	// the token is a credential, it's never logged
+	log.Infof("authenticating with the token defined in %s", tokenEnvVar)
+	return WithToken(ctx, oktetoURL, TokenFromEnvVar())
+}
+
 // WithToken authenticates the user with an API token
 func WithToken(ctx context.Context, url, token string) (*okteto.User, error) {
+	if err := validateToken(token); err != nil {
+		return nil, err
+	}
+
 	return okteto.AuthWithToken(ctx, url, token)
 }
 
+// validateToken checks that token looks like an API token before sending it to the server
+func validateToken(token string) error {
+	if token == "" {
+		return fmt.Errorf("the API token is empty")
+	}
+
+	for _, r := range token {
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return fmt.Errorf("the API token has invalid characters")
+		}
+	}
+
+	return nil
+}
+
 //WithBrowser authenticates the user with the brower
 func WithBrowser(ctx context.Context, oktetoURL string) (*okteto.User, error) {
 	h, err := StartWithBrowser(ctx, oktetoURL)
@@ -0,0 +1,60 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_validateToken(t *testing.T) {
+	var tests = []struct {
+		name      string
+		token     string
+		expectErr bool
+	}{
+		{name: "valid", token: "6TzLqUuTbXgRBeHy9bLDCRvbhmsRDxEyAZvEL9TD"},
+		{name: "empty", token: "", expectErr: true},
+		{name: "inner-space", token: "6TzLqUuTb XgRBeHy9", expectErr: true},
+		{name: "newline", token: "6TzLqUuTbXgRBeHy9\n", expectErr: true},
+		{name: "control", token: "6TzLqUuTb\x00XgRBeHy9", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateToken(tt.token)
+			if tt.expectErr && err == nil {
+				t.Error("expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestTokenFromEnvVar(t *testing.T) {
+	defer os.Unsetenv(tokenEnvVar)
+
+	os.Unsetenv(tokenEnvVar)
+	if got := TokenFromEnvVar(); got != "" {
+		t.Errorf("got '%s', expected an empty token", got)
+	}
+
+	os.Setenv(tokenEnvVar, " token \n")
+	if got := TokenFromEnvVar(); got != "token" {
+		t.Errorf("got '%s', expected 'token'", got)
+	}
+}
@@ -50,7 +50,7 @@ func translate(ctx context.Context, s *model.Stack, forceBuild, noCache bool) er
 func translateEnvVars(s *model.Stack) error {
 	var err error
 	for name, svc := range s.Services {
-		svc.Image, err = model.ExpandEnv(svc.Image)
+		svc.Image, err = model.ExpandEnvLenient(svc.Image)
 		if err != nil {
 			return err
 		}
@@ -70,7 +70,7 @@ func translateEnvVars(s *model.Stack) error {
 
 func translateEnvFile(svc *model.Service, filename string) error {
 	var err error
-	filename, err = model.ExpandEnv(filename)
+	filename, err = model.ExpandEnvLenient(filename)
 	if err != nil {
 		return err
 	}
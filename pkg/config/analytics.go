@@ -0,0 +1,85 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// disableAnalyticsEnvVar disables the analytics, whatever the settings say
+	disableAnalyticsEnvVar = "OKTETO_DISABLE_ANALYTICS"
+
+	// noAnalyticsFileName is the file that disabled the analytics before the analytics setting existed
+	noAnalyticsFileName = ".noanalytics"
+)
+
+// IsAnalyticsEnabled returns false if OKTETO_DISABLE_ANALYTICS is set, or if the analytics are disabled in the settings.
+// If the settings can't be read, the analytics are disabled, so an opt-out is never ignored
+func IsAnalyticsEnabled() bool {
+	if v := os.Getenv(disableAnalyticsEnvVar); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil || disabled {
+			return false
+		}
+	}
+
+	home, err := getOktetoHomePath()
+	if err != nil {
+		log.Infof("analytics disabled, the okteto folder can't be found: %s", err)
+		return false
+	}
+
+	if _, err := os.Stat(filepath.Join(home, noAnalyticsFileName)); !os.IsNotExist(err) {
+		return false
+	}
+
+	s, err := loadSettings(filepath.Join(home, settingsFileName))
+	if err != nil {
+		log.Infof("analytics disabled, the settings can't be read: %s", err)
+		return false
+	}
+
+	return s.Analytics()
+}
+
+// SetAnalyticsEnabled persists the analytics choice of the user in the settings
+func SetAnalyticsEnabled(enabled bool) error {
+	s, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	s.SetAnalytics(enabled)
+	if err := s.Save(); err != nil {
+		return err
+	}
+
+	if enabled {
+		home, err := GetOktetoHomeE()
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(filepath.Join(home, noAnalyticsFileName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsAnalyticsEnabled(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv(disableAnalyticsEnvVar)
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	os.Unsetenv(disableAnalyticsEnvVar)
+	if !IsAnalyticsEnabled() {
+		t.Fatal("analytics are disabled by default")
+	}
+
+	for _, v := range []string{"1", "true", "yes"} {
+		os.Setenv(disableAnalyticsEnvVar, v)
+		if IsAnalyticsEnabled() {
+			t.Errorf("analytics are enabled with %s=%s", disableAnalyticsEnvVar, v)
+		}
+	}
+
+	os.Setenv(disableAnalyticsEnvVar, "false")
+	if !IsAnalyticsEnabled() {
+		t.Errorf("analytics are disabled with %s=false", disableAnalyticsEnvVar)
+	}
+
+	os.Unsetenv(disableAnalyticsEnvVar)
+	if err := SetAnalyticsEnabled(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsAnalyticsEnabled() {
+		t.Error("analytics are enabled after disabling them")
+	}
+
+	if err := SetAnalyticsEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsAnalyticsEnabled() {
+		t.Error("analytics are disabled after enabling them")
+	}
+
+	legacy := filepath.Join(dir, noAnalyticsFileName)
+	if err := ioutil.WriteFile(legacy, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsAnalyticsEnabled() {
+		t.Errorf("analytics are enabled with %s", legacy)
+	}
+
+	if err := SetAnalyticsEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsAnalyticsEnabled() {
+		t.Errorf("analytics are disabled, %s wasn't removed", legacy)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, settingsFileName), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsAnalyticsEnabled() {
+		t.Error("analytics are enabled with invalid settings")
+	}
+}
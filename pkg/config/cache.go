@@ -0,0 +1,151 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const cacheFolderName = "cache"
+
+// GetCacheDir returns the folder for downloaded binaries and other files that can be recreated
+func GetCacheDir() string {
+	d, err := GetCacheDirE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return d
+}
+
+// GetCacheDirE returns the folder for downloaded binaries and other files that can be recreated, or an error if it can't be created.
+// It's kept apart from the credentials of the okteto home, so it can live in a different volume and be cleared safely
+func GetCacheDirE() (string, error) {
+	d, err := getCacheDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
+
+func getCacheDirPath() (string, error) {
+	if _, ok := os.LookupEnv("OKTETO_FOLDER"); ok {
+		return oktetoHomeCacheDir()
+	}
+
+	if d := getCacheFolder(runtime.GOOS, os.Getenv); d != "" {
+		return d, nil
+	}
+
+	return oktetoHomeCacheDir()
+}
+
+func oktetoHomeCacheDir() (string, error) {
+	home, err := getOktetoHomePath()
+	if err != nil {
+		return "", err
+	}
+
+	return internalFolder(home, cacheFolderName), nil
+}
+
+// getCacheFolder returns the platform cache folder of okteto: $XDG_CACHE_HOME/okteto on linux,
+// ~/Library/Caches/okteto on macOS and %LOCALAPPDATA%\okteto\cache on windows.
+// It returns an empty string when the platform folder isn't defined, so the okteto home is used instead
+func getCacheFolder(goos string, getenv func(string) string) string {
+	switch goos {
+	case "linux":
+		if xdg := getenv("XDG_CACHE_HOME"); xdg != "" {
+			return filepath.Join(xdg, oktetoXDGFolderName)
+		}
+	case "darwin":
+		if home := getenv("HOME"); home != "" {
+			return filepath.Join(home, "Library", "Caches", oktetoXDGFolderName)
+		}
+	case "windows":
+		if local := getenv("LOCALAPPDATA"); local != "" {
+			return filepath.Join(local, oktetoXDGFolderName, cacheFolderName)
+		}
+	}
+
+	return ""
+}
+
+// CleanCache removes the content of the cache folder. It refuses to clean folders that hold credentials
+func CleanCache() error {
+	if IsReadOnly() {
+		return newError(ErrReadOnly, nil, "the cache can't be cleaned when okteto is running in read-only mode")
+	}
+
+	d, err := getCacheDirPath()
+	if err != nil {
+		return err
+	}
+
+	return cleanCacheDir(d)
+}
+
+func cleanCacheDir(d string) error {
+	if err := isSafeToClean(d); err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(d)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read %s: %w", d, err)
+	}
+
+	for _, e := range entries {
+		p := filepath.Join(d, e.Name())
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+	}
+
+	log.Infof("cleaned the cache folder %s", d)
+	return nil
+}
+
+// isSafeToClean returns an error if d is the okteto home, the user home or the root of the filesystem
+func isSafeToClean(d string) error {
+	d = filepath.Clean(d)
+	if filepath.Dir(d) == d {
+		return fmt.Errorf("refusing to clean %s, it's the root of the filesystem", d)
+	}
+
+	if home, err := GetUserHomeDirE(); err == nil && filepath.Clean(home) == d {
+		return fmt.Errorf("refusing to clean %s, it's your home folder", d)
+	}
+
+	if home, err := getOktetoHomePath(); err == nil && filepath.Clean(home) == d {
+		return fmt.Errorf("refusing to clean %s, it's the okteto home", d)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_getCacheFolder(t *testing.T) {
+	var tests = []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "linux-xdg",
+			goos:     "linux",
+			env:      map[string]string{"XDG_CACHE_HOME": "/home/okteto/.cache"},
+			expected: filepath.Join("/home/okteto/.cache", "okteto"),
+		},
+		{
+			name:     "linux-no-xdg",
+			goos:     "linux",
+			env:      map[string]string{"HOME": "/home/okteto"},
+			expected: "",
+		},
+		{
+			name:     "darwin",
+			goos:     "darwin",
+			env:      map[string]string{"HOME": "/Users/okteto", "XDG_CACHE_HOME": "/Users/okteto/.cache"},
+			expected: filepath.Join("/Users/okteto", "Library", "Caches", "okteto"),
+		},
+		{
+			name:     "windows",
+			goos:     "windows",
+			env:      map[string]string{"LOCALAPPDATA": "C:/Users/okteto/AppData/Local"},
+			expected: filepath.Join("C:/Users/okteto/AppData/Local", "okteto", "cache"),
+		},
+		{
+			name:     "windows-no-localappdata",
+			goos:     "windows",
+			env:      map[string]string{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := getCacheFolder(tt.goos, getenv); got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetCacheDirE(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	got, err := GetCacheDirE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := internalFolder(dir, cacheFolderName)
+	if got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if info, err := os.Stat(got); err != nil || !info.IsDir() {
+		t.Errorf("%s wasn't created", got)
+	}
+}
+
+func TestCleanCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	cache, err := GetCacheDirE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := filepath.Join(dir, ".token.json")
+	if err := ioutil.WriteFile(token, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(cache, "bin"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(cache, "syncthing"), []byte("binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CleanCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ioutil.ReadDir(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("the cache wasn't cleaned, %d entries left", len(entries))
+	}
+
+	if _, err := os.Stat(token); err != nil {
+		t.Errorf("the credentials were removed: %s", err)
+	}
+
+	if err := cleanCacheDir(dir); err == nil {
+		t.Error("cleaning the okteto home didn't fail")
+	}
+
+	if _, err := os.Stat(token); err != nil {
+		t.Errorf("the credentials were removed: %s", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const completionFolderName = "completion"
+
+// completionShells are the shells okteto can write completion scripts for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// WriteCompletion writes the completion script of shell to <okteto home>/completion/<shell> and returns its path, so the user can source it.
+// If shell is empty, it's detected from $SHELL. generate writes the script of a shell, an existing script is overwritten
+func WriteCompletion(shell string, generate func(shell string, w io.Writer) error) (string, error) {
+	shell, err := getCompletionShell(shell, os.Getenv("SHELL"))
+	if err != nil {
+		return "", err
+	}
+
+	if IsReadOnly() {
+		return "", newError(ErrReadOnly, nil, "the %s completion can't be written, okteto is running in read-only mode", shell)
+	}
+
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return "", err
+	}
+
+	return writeCompletion(internalFolder(home, completionFolderName), shell, generate)
+}
+
+func writeCompletion(d, shell string, generate func(shell string, w io.Writer) error) (string, error) {
+	var buf bytes.Buffer
+	if err := generate(shell, &buf); err != nil {
+		return "", fmt.Errorf("failed to generate the %s completion: %w", shell, err)
+	}
+
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(d, shell)
+	if err := WriteFileAtomic(path, buf.Bytes(), 0600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// getCompletionShell returns shell, or the shell of the path in $SHELL if it's empty
+func getCompletionShell(shell, env string) (string, error) {
+	if shell == "" {
+		if env == "" {
+			return "", fmt.Errorf("the shell can't be detected because $SHELL is not set, specify one of: %s", strings.Join(completionShells, ", "))
+		}
+
+		shell = strings.TrimSuffix(filepath.Base(env), ".exe")
+	}
+
+	for _, s := range completionShells {
+		if s == shell {
+			return shell, nil
+		}
+	}
+
+	return "", fmt.Errorf("completion is not supported for '%s', use one of: %s", shell, strings.Join(completionShells, ", "))
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_getCompletionShell(t *testing.T) {
+	var tests = []struct {
+		name      string
+		shell     string
+		env       string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "explicit",
+			shell:    "fish",
+			env:      "/bin/bash",
+			expected: "fish",
+		},
+		{
+			name:     "detected",
+			env:      "/usr/local/bin/zsh",
+			expected: "zsh",
+		},
+		{
+			name:      "unsupported",
+			shell:     "tcsh",
+			expectErr: true,
+		},
+		{
+			name:      "unsupported-detected",
+			env:       "/bin/sh",
+			expectErr: true,
+		},
+		{
+			name:      "no-shell",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCompletionShell(tt.shell, tt.env)
+			if err != nil {
+				if !tt.expectErr {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if tt.expectErr {
+				t.Fatalf("expected error, got %s", got)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteCompletion(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	for _, content := range []string{"first version of the bash completion", "second"} {
+		generate := func(shell string, w io.Writer) error {
+			_, err := fmt.Fprintf(w, "%s: %s", shell, content)
+			return err
+		}
+
+		path, err := WriteCompletion("bash", generate)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := filepath.Join(internalFolder(dir, completionFolderName), "bash")
+		if path != expected {
+			t.Fatalf("got %s, expected %s", path, expected)
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != "bash: "+content {
+			t.Errorf("got '%s', expected the script to be overwritten", string(b))
+		}
+	}
+
+	failing := func(shell string, w io.Writer) error {
+		return fmt.Errorf("failed")
+	}
+
+	if _, err := WriteCompletion("zsh", failing); err == nil {
+		t.Error("expected the error of the generator")
+	}
+
+	if _, err := os.Stat(filepath.Join(internalFolder(dir, completionFolderName), "zsh")); !os.IsNotExist(err) {
+		t.Errorf("the zsh completion was written after the generator failed")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -27,7 +28,21 @@ import (
 )
 
 const (
-	oktetoFolderName = ".okteto"
+	oktetoFolderName    = ".okteto"
+	oktetoXDGFolderName = "okteto"
+	logFileName         = "okteto.log"
+
+	// internalFolderName is the folder of the okteto home for the files that don't belong to a namespace.
+	// It starts with a dot, so it never collides with the folder of a namespace
+	internalFolderName = ".okteto-internal"
+
+	// defaultsManifestName is the user-level manifest merged under the manifest of every project
+	defaultsManifestName = "defaults.yml"
+
+	// inClusterKubeConfigName is the kubeconfig okteto uses when it runs inside a cluster
+	inClusterKubeConfigName = "kubeconfig"
+
+	defaultFolderPermissions os.FileMode = 0700
 )
 
 // VersionString the version of the cli
@@ -36,81 +51,471 @@ var VersionString string
 var timeout time.Duration
 var tOnce sync.Once
 
+// manifestTimeout is the base timeout defined in the okteto manifest
+var manifestTimeout time.Duration
+
+// timeoutOverridden is true once SetTimeout is called
+var timeoutOverridden bool
+
+// windowsDeviceNames are the folder names reserved by Windows
+var windowsDeviceNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+var actionTimeouts = map[string]time.Duration{}
+var atMutex sync.Mutex
+
+var xdgOnce sync.Once
+
+// oktetoFolderValue is the last value of OKTETO_FOLDER, and oktetoFolderPath its absolute path
+var oktetoFolderValue string
+var oktetoFolderPath string
+var oktetoFolderMutex sync.Mutex
+
+var homeDir string
+var homeErr error
+var hOnce sync.Once
+
+var folderPermissions os.FileMode
+
+// serviceAccountTokenFile is the token mounted in the pods running in a cluster
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+var pOnce sync.Once
+
 //GetBinaryName returns the name of the binary
 func GetBinaryName() string {
 	return filepath.Base(GetBinaryFullPath())
 }
 
-//GetBinaryFullPath returns the name of the binary
+//GetBinaryFullPath returns the name of the binary as it was invoked. Use ResolveBinaryPath to get the actual file
 func GetBinaryFullPath() string {
 	return os.Args[0]
 }
 
+// ResolveBinaryPath returns the path of the running binary with all the symlinks resolved.
+// This is the file that must be overwritten during an in-place upgrade.
+// It falls back to os.Args[0] if the path can't be resolved
+func ResolveBinaryPath() (string, error) {
+	p, err := os.Executable()
+	if err == nil {
+		p, err = filepath.EvalSymlinks(p)
+	}
+
+	if err != nil {
+		log.Infof("failed to resolve the path of the binary: %s", err)
+		if len(os.Args) == 0 || os.Args[0] == "" {
+			return "", fmt.Errorf("couldn't resolve the path of the binary: %w", err)
+		}
+
+		return os.Args[0], nil
+	}
+
+	return p, nil
+}
+
 // GetOktetoHome returns the path of the okteto folder
 func GetOktetoHome() string {
-	if v, ok := os.LookupEnv("OKTETO_FOLDER"); ok {
-		if !model.FileExists(v) {
-			log.Fatalf("OKTETO_FOLDER doesn't exist: %s", v)
-		}
+	d, err := GetOktetoHomeE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
 
-		return v
+	return d
+}
+
+// GetOktetoHomeE returns the path of the okteto folder, or an error if it can't be created
+func GetOktetoHomeE() (string, error) {
+	d, err := getOktetoHomePath()
+	if err != nil {
+		return "", err
 	}
 
-	home := GetUserHomeDir()
-	d := filepath.Join(home, oktetoFolderName)
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
 
-	if err := os.MkdirAll(d, 0700); err != nil {
-		log.Fatalf("failed to create %s: %s", d, err)
+// OktetoHomePath returns the path of the okteto folder without creating any folder.
+// It never fails, an empty string is returned if the path can't be computed.
+// Use it to display the path, GetOktetoHome to store files in it
+func OktetoHomePath() string {
+	d, err := oktetoHomePath(lookupUserHomeDir)
+	if err != nil {
+		log.Infof("failed to compute the okteto folder: %s", err)
+		return ""
 	}
 
 	return d
 }
 
+// getOktetoHomePath returns the path of the okteto folder without creating it
+func getOktetoHomePath() (string, error) {
+	return oktetoHomePath(GetUserHomeDirE)
+}
+
+// oktetoHomePath computes the path of the okteto folder, getHome returns the home dir of the user
+func oktetoHomePath(getHome func() (string, error)) (string, error) {
+	if v, ok, err := lookupOktetoFolder(); ok {
+		return v, err
+	}
+
+	if os.Getenv("OKTETO_PROJECT_LOCAL") == "1" {
+		if d, ok := FindProjectOktetoFolder(); ok {
+			return d, nil
+		}
+	}
+
+	home, err := getHome()
+	if err != nil {
+		return "", err
+	}
+
+	return getOktetoFolder(home, runtime.GOOS), nil
+}
+
+// lookupOktetoFolder returns OKTETO_FOLDER as an absolute path. A relative path is resolved against the working directory
+// the first time it's seen, so the okteto folder doesn't change if the working directory changes later
+func lookupOktetoFolder() (string, bool, error) {
+	v, ok := os.LookupEnv("OKTETO_FOLDER")
+	if !ok {
+		return "", false, nil
+	}
+
+	if v == "" {
+		return "", true, newError(ErrHomeNotFound, nil, "OKTETO_FOLDER is empty")
+	}
+
+	oktetoFolderMutex.Lock()
+	defer oktetoFolderMutex.Unlock()
+	if v == oktetoFolderValue {
+		return oktetoFolderPath, true, nil
+	}
+
+	abs, err := filepath.Abs(v)
+	if err != nil {
+		return "", true, newError(ErrHomeNotFound, err, "failed to resolve OKTETO_FOLDER %s", v)
+	}
+
+	if abs != v {
+		log.Debugf("OKTETO_FOLDER '%s' resolved to %s", v, abs)
+	}
+
+	oktetoFolderValue = v
+	oktetoFolderPath = abs
+	return abs, true, nil
+}
+
+// IsReadOnly returns true if OKTETO_READONLY is set, meaning that okteto must not create any folder
+func IsReadOnly() bool {
+	return os.Getenv("OKTETO_READONLY") == "1"
+}
+
+// ensureDir creates the folder d if it doesn't exist. In read-only mode, it returns an error instead
+func ensureDir(d string) error {
+	if IsReadOnly() {
+		info, err := os.Stat(d)
+		if err != nil {
+			return newError(ErrReadOnly, err, "%s doesn't exist and okteto is running in read-only mode", d)
+		}
+
+		if !info.IsDir() {
+			return newError(ErrHomeNotWritable, nil, "%s is not a directory", d)
+		}
+
+		return nil
+	}
+
+	if err := os.MkdirAll(d, getFolderPermissions()); err != nil {
+		return newError(ErrHomeNotWritable, err, "failed to create %s", d)
+	}
+
+	return nil
+}
+
+// getOktetoFolder returns $XDG_CONFIG_HOME/okteto on linux when XDG_CONFIG_HOME is defined,
+// unless the legacy $HOME/.okteto folder already exists
+func getOktetoFolder(home, goos string) string {
+	legacy := filepath.Join(home, oktetoFolderName)
+	if goos != "linux" {
+		return legacy
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfigHome == "" {
+		return legacy
+	}
+
+	xdg := filepath.Join(xdgConfigHome, oktetoXDGFolderName)
+	if !model.FileExists(legacy) {
+		return xdg
+	}
+
+	if model.FileExists(xdg) {
+		xdgOnce.Do(func() {
+			log.Infof("both %s and %s exist, using %s", legacy, xdg, legacy)
+		})
+	}
+
+	return legacy
+}
+
+// FindProjectOktetoFolder looks for a .okteto folder in the current directory and its parents.
+// The search stops at the user home dir or at the root of the filesystem
+func FindProjectOktetoFolder() (string, bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Infof("failed to get the current directory: %s", err)
+		return "", false
+	}
+
+	home, err := GetUserHomeDirE()
+	if err != nil {
+		log.Infof("failed to get the home directory: %s", err)
+		home = ""
+	}
+
+	return findProjectOktetoFolder(wd, home)
+}
+
+func findProjectOktetoFolder(from, stop string) (string, bool) {
+	dir := filepath.Clean(from)
+	stop = filepath.Clean(stop)
+	for dir != stop {
+		candidate := filepath.Join(dir, oktetoFolderName)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", false
+}
+
+// GetDefaultsManifestFileE returns the path of the user-level manifest merged under the manifest of every project,
+// or an error if the okteto folder can't be created
+func GetDefaultsManifestFileE() (string, error) {
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, defaultsManifestName), nil
+}
+
+// GetLogFile returns the path of the okteto log file. It can be overridden with OKTETO_LOG_FILE
+func GetLogFile() string {
+	f, err := GetLogFileE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return f
+}
+
+// GetLogFileE returns the path of the okteto log file, or an error if the okteto folder can't be created
+func GetLogFileE() (string, error) {
+	if v := os.Getenv("OKTETO_LOG_FILE"); v != "" {
+		return v, nil
+	}
+
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, logFileName), nil
+}
+
 // GetNamespaceHome returns the path of the folder
 func GetNamespaceHome(namespace string) string {
-	okHome := GetOktetoHome()
-	d := filepath.Join(okHome, namespace)
-
-	if err := os.MkdirAll(d, 0700); err != nil {
-		log.Fatalf("failed to create %s: %s", d, err)
+	d, err := GetNamespaceHomeE(namespace)
+	if err != nil {
+		log.Fatalf("%s", err)
 	}
 
 	return d
 }
 
+// GetNamespaceHomeE returns the path of the folder, or an error if it can't be created
+func GetNamespaceHomeE(namespace string) (string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return "", err
+	}
+
+	return p.NamespaceHome(namespace)
+}
+
+// ListNamespaceHomes returns the names of the namespaces with a folder in the okteto home
+func ListNamespaceHomes() ([]string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ListNamespaceHomes()
+}
+
+// StaleNamespaceHomes returns the names of the namespaces whose folder wasn't modified in the last maxAge.
+// Use it to preview what PruneOldNamespaceHomes removes
+func StaleNamespaceHomes(maxAge time.Duration) ([]string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.StaleNamespaceHomes(maxAge)
+}
+
+// PruneOldNamespaceHomes removes the namespace folders that weren't modified in the last maxAge and returns their namespaces
+func PruneOldNamespaceHomes(maxAge time.Duration) ([]string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.PruneOldNamespaceHomes(maxAge)
+}
+
 // GetDeploymentHome returns the path of the folder
 func GetDeploymentHome(namespace, name string) string {
-	okHome := GetOktetoHome()
-	d := filepath.Join(okHome, namespace, name)
-
-	if err := os.MkdirAll(d, 0700); err != nil {
-		log.Fatalf("failed to create %s: %s", d, err)
+	d, err := GetDeploymentHomeE(namespace, name)
+	if err != nil {
+		log.Fatalf("%s", err)
 	}
 
 	return d
 }
 
+// GetDeploymentHomeE returns the path of the folder, or an error if it can't be created
+func GetDeploymentHomeE(namespace, name string) (string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return "", err
+	}
+
+	return p.DeploymentHome(namespace, name)
+}
+
+// RemoveDeploymentHome removes the folder of a deployment, if it exists
+func RemoveDeploymentHome(namespace, name string) error {
+	p, err := DefaultPaths()
+	if err != nil {
+		return err
+	}
+
+	return p.RemoveDeploymentHome(namespace, name)
+}
+
+// ListDeploymentFiles returns the files stored in the folder of a deployment, relative to it
+func ListDeploymentFiles(namespace, name string) ([]string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ListDeploymentFiles(namespace, name)
+}
+
+func validatePathComponent(value string) error {
+	if value == "" || value == "." || value == ".." {
+		return newError(ErrInvalidName, nil, "'%s' is not a valid folder name", value)
+	}
+
+	if strings.ContainsAny(value, `/\`) {
+		return newError(ErrInvalidName, nil, "'%s' can't contain path separators", value)
+	}
+
+	return nil
+}
+
 // GetUserHomeDir returns the OS home dir
 func GetUserHomeDir() string {
+	home, err := GetUserHomeDirE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return home
+}
+
+// GetUserHomeDirE returns the OS home dir, or an error if it can't be determined.
+// The result is cached, call ResetUserHomeDir to compute it again
+func GetUserHomeDirE() (string, error) {
+	hOnce.Do(func() {
+		homeDir, homeErr = getUserHomeDir()
+	})
+
+	return homeDir, homeErr
+}
+
+// lookupUserHomeDir returns the home dir of the user like GetUserHomeDirE, but it doesn't create OKTETO_HOME
+func lookupUserHomeDir() (string, error) {
+	if v, ok := os.LookupEnv("OKTETO_HOME"); ok {
+		return v, nil
+	}
+
+	return GetUserHomeDirE()
+}
+
+// ResetUserHomeDir invalidates the cached home dir. Meant to be used by tests that change OKTETO_HOME
+func ResetUserHomeDir() {
+	hOnce = sync.Once{}
+	homeDir = ""
+	homeErr = nil
+}
+
+func getUserHomeDir() (string, error) {
 	if v, ok := os.LookupEnv("OKTETO_HOME"); ok {
-		if !model.FileExists(v) {
-			log.Fatalf("OKTETO_HOME points to a non-existing directory: %s", v)
+		info, err := os.Stat(v)
+		switch {
+		case os.IsNotExist(err):
+			if err := ensureDir(v); err != nil {
+				return "", newError(ErrHomeNotWritable, err, "failed to create OKTETO_HOME")
+			}
+		case err != nil:
+			return "", newError(ErrHomeNotFound, err, "failed to check OKTETO_HOME %s", v)
+		case !info.IsDir():
+			return "", newError(ErrHomeNotFound, nil, "OKTETO_HOME points to a file instead of a directory: %s", v)
 		}
 
-		return v
+		return v, nil
 	}
 
 	if runtime.GOOS == "windows" {
 		home, err := homedirWindows()
 		if err != nil {
-			log.Fatalf("couldn't determine your home directory: %s", err)
+			return "", newError(ErrHomeNotFound, err, "couldn't determine your home directory")
 		}
 
-		return home
+		return home, nil
 	}
 
-	return os.Getenv("HOME")
+	home := os.Getenv("HOME")
+	if home == "" && IsRunningInCluster() {
+		home = os.TempDir()
+		log.Infof("HOME is not defined, using %s as the home directory", home)
+	}
 
+	return home, nil
+}
+
+// IsRunningInCluster returns true if okteto is running in a pod of a kubernetes cluster
+func IsRunningInCluster() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return false
+	}
+
+	return model.FileExists(serviceAccountTokenFile)
 }
 
 func homedirWindows() (string, error) {
@@ -124,42 +529,164 @@ func homedirWindows() (string, error) {
 
 	drive := os.Getenv("HOMEDRIVE")
 	path := os.Getenv("HOMEPATH")
-	home := drive + path
-	if drive == "" || path == "" {
+	if drive != "" && path != "" {
+		return drive + path, nil
+	}
+
+	home, err := knownHomeFolder()
+	if err != nil || home == "" {
+		log.Infof("failed to get the profile folder from the known folders API: %v", err)
 		return "", fmt.Errorf("HOME, HOMEDRIVE, HOMEPATH, or USERPROFILE are empty. Use $OKTETO_HOME to set your home directory")
 	}
 
 	return home, nil
 }
 
-// GetKubeConfigFile returns the path to the kubeconfig file, taking the KUBECONFIG env var into consideration
+// getFolderPermissions returns the permissions used to create the okteto folders
+func getFolderPermissions() os.FileMode {
+	pOnce.Do(func() {
+		folderPermissions = defaultFolderPermissions
+		v, ok := os.LookupEnv("OKTETO_FOLDER_PERMISSIONS")
+		if !ok {
+			return
+		}
+
+		folderPermissions = parseFolderPermissions(v)
+		if folderPermissions != defaultFolderPermissions {
+			log.Infof("OKTETO_FOLDER_PERMISSIONS applied: '%#o'", folderPermissions)
+		}
+	})
+
+	return folderPermissions
+}
+
+func parseFolderPermissions(v string) os.FileMode {
+	parsed, err := strconv.ParseUint(v, 8, 32)
+	if err != nil {
+		log.Infof("'%s' is not a valid octal permission, ignoring", v)
+		return defaultFolderPermissions
+	}
+
+	if parsed > 0777 {
+		log.Infof("'%s' exceeds the maximum permission 0777, ignoring", v)
+		return defaultFolderPermissions
+	}
+
+	return os.FileMode(parsed)
+}
+
+// GetKubeConfigFile returns the path to the kubeconfig file, taking the OKTETO_KUBECONFIG and KUBECONFIG env vars into consideration
 func GetKubeConfigFile() string {
+	return GetKubeConfigFiles()[0]
+}
+
+// GetKubeConfigFiles returns the paths to all the kubeconfig files, taking the OKTETO_KUBECONFIG and KUBECONFIG env vars into consideration.
+// OKTETO_KUBECONFIG takes precedence over KUBECONFIG, so okteto can use its own kubeconfig without affecting other tools.
+// The paths are returned in the same order as defined in the env var, so they can be merged the same way kubectl does.
+// Duplicated and missing files are dropped, see NormalizeKubeConfig.
+// Inside a cluster the kubeconfig in the okteto folder takes precedence, so okteto doesn't write to a mounted kubeconfig
+func GetKubeConfigFiles() []string {
+	for _, env := range []string{"OKTETO_KUBECONFIG", "KUBECONFIG"} {
+		if files := normalizeKubeConfigFiles(splitKubeConfigEnv(os.Getenv(env), runtime.GOOS), env); len(files) > 0 {
+			return files
+		}
+	}
+
 	home := GetUserHomeDir()
-	kubeconfig := filepath.Join(home, ".kube", "config")
-	kubeconfigEnv := os.Getenv("KUBECONFIG")
-	if len(kubeconfigEnv) > 0 {
-		kubeconfig = splitKubeConfigEnv(kubeconfigEnv)
+	files := []string{filepath.Join(home, ".kube", "config")}
+	if IsRunningInCluster() {
+		files = append([]string{filepath.Join(GetOktetoHome(), inClusterKubeConfigName)}, files...)
 	}
-	return kubeconfig
+
+	return files
 }
 
-func splitKubeConfigEnv(value string) string {
-	if runtime.GOOS == "windows" {
-		return strings.Split(value, ";")[0]
+// NormalizeKubeConfig splits the paths of a KUBECONFIG value, resolves them to absolute clean paths and drops duplicated and missing files.
+// If none of the files exists, the first one is kept, so it can be created
+func NormalizeKubeConfig(value string) []string {
+	return normalizeKubeConfigFiles(splitKubeConfigEnv(value, runtime.GOOS), "KUBECONFIG")
+}
+
+// normalizeKubeConfigFiles resolves relative paths against the current folder, so the kubeconfig in use doesn't depend on where okteto runs.
+// Paths that can't be resolved, duplicated paths and missing files are dropped, as clientcmd merges them into confusing duplicated contexts
+func normalizeKubeConfigFiles(files []string, env string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	var firstMissing string
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			log.Infof("failed to resolve '%s' defined in %s, ignoring: %s", f, env, err)
+			continue
+		}
+
+		if abs != filepath.Clean(f) {
+			log.Infof("resolved relative path '%s' defined in %s to '%s'", f, env, abs)
+		}
+
+		if seen[abs] {
+			log.Debugf("dropping '%s' defined in %s: it's duplicated", f, env)
+			continue
+		}
+		seen[abs] = true
+
+		if _, err := os.Stat(abs); err != nil && os.IsNotExist(err) {
+			log.Debugf("dropping '%s' defined in %s: it doesn't exist", f, env)
+			if firstMissing == "" {
+				firstMissing = abs
+			}
+			continue
+		}
+
+		result = append(result, abs)
 	}
-	return strings.Split(value, ":")[0]
+
+	if len(result) == 0 && firstMissing != "" {
+		return []string{firstMissing}
+	}
+
+	return result
+}
+
+func splitKubeConfigEnv(value, goos string) []string {
+	separator := ":"
+	if goos == "windows" {
+		separator = ";"
+	}
+
+	files := []string{}
+	for _, f := range strings.Split(value, separator) {
+		if f == "" {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	return files
 }
 
 // GetTimeout returns the per-action timeout
 func GetTimeout() time.Duration {
+	atMutex.Lock()
+	defer atMutex.Unlock()
+
+	return getTimeout()
+}
+
+// getTimeout returns the per-action timeout, the caller must hold atMutex
+func getTimeout() time.Duration {
 	tOnce.Do(func() {
 		timeout = (30 * time.Second)
+		if manifestTimeout > 0 {
+			timeout = manifestTimeout
+		}
+
 		t, ok := os.LookupEnv("OKTETO_TIMEOUT")
 		if !ok {
 			return
 		}
 
-		parsed, err := time.ParseDuration(t)
+		parsed, err := parseTimeout(t)
 		if err != nil {
 			log.Infof("'%s' is not a valid duration, ignoring", t)
 			return
@@ -171,3 +698,117 @@ func GetTimeout() time.Duration {
 
 	return timeout
 }
+
+// GetTimeoutE returns the per-action timeout, or an ErrInvalidTimeout error if OKTETO_TIMEOUT can't be parsed.
+// GetTimeout ignores invalid values instead
+func GetTimeoutE() (time.Duration, error) {
+	if t, ok := os.LookupEnv("OKTETO_TIMEOUT"); ok {
+		if _, err := parseTimeout(t); err != nil {
+			return GetTimeout(), err
+		}
+	}
+
+	return GetTimeout(), nil
+}
+
+// SetTimeout overrides the per-action timeout, taking precedence over OKTETO_TIMEOUT.
+// Call it before any action runs, e.g. from a command line flag. Durations <= 0 are ignored
+func SetTimeout(d time.Duration) {
+	if d <= 0 {
+		log.Warnf("ignoring timeout '%s', it must be greater than zero", d.String())
+		return
+	}
+
+	atMutex.Lock()
+	defer atMutex.Unlock()
+
+	tOnce.Do(func() {})
+	timeout = d
+	timeoutOverridden = true
+	actionTimeouts = map[string]time.Duration{}
+	log.Infof("timeout applied: '%s'", d.String())
+}
+
+// SetManifestTimeout sets the timeout defined in the okteto manifest as the base per-action timeout.
+// OKTETO_TIMEOUT and SetTimeout take precedence over it. Durations <= 0 are ignored
+func SetManifestTimeout(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	atMutex.Lock()
+	defer atMutex.Unlock()
+
+	manifestTimeout = d
+	if timeoutOverridden {
+		return
+	}
+
+	tOnce = sync.Once{}
+	actionTimeouts = map[string]time.Duration{}
+}
+
+// ResetTimeout discards the timeouts set by SetTimeout and SetManifestTimeout, so the next call to GetTimeout reads OKTETO_TIMEOUT again
+func ResetTimeout() {
+	atMutex.Lock()
+	defer atMutex.Unlock()
+
+	tOnce = sync.Once{}
+	timeout = 0
+	manifestTimeout = 0
+	timeoutOverridden = false
+	actionTimeouts = map[string]time.Duration{}
+}
+
+// parseTimeout parses a Go duration (e.g. "1m30s") or a plain integer interpreted as seconds
+func parseTimeout(t string) (time.Duration, error) {
+	parsed, err := time.ParseDuration(t)
+	if err == nil {
+		return parsed, nil
+	}
+
+	seconds, atoiErr := strconv.Atoi(t)
+	if atoiErr != nil {
+		return 0, newError(ErrInvalidTimeout, err, "'%s' is not a valid duration", t)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// GetTimeoutFor returns the timeout of a given action (e.g. "up", "build").
+// OKTETO_TIMEOUT_<ACTION> takes precedence over the value returned by GetTimeout
+func GetTimeoutFor(action string) time.Duration {
+	atMutex.Lock()
+	defer atMutex.Unlock()
+
+	if t, ok := actionTimeouts[action]; ok {
+		return t
+	}
+
+	t := getActionTimeout(action)
+	actionTimeouts[action] = t
+	return t
+}
+
+// getActionTimeout returns the timeout of action, the caller must hold atMutex
+func getActionTimeout(action string) time.Duration {
+	key := fmt.Sprintf("OKTETO_TIMEOUT_%s", strings.ToUpper(strings.ReplaceAll(action, "-", "_")))
+	t, ok := os.LookupEnv(key)
+	if !ok {
+		return getTimeout()
+	}
+
+	parsed, err := parseTimeout(t)
+	if err != nil {
+		log.Infof("'%s' is not a valid duration for %s, ignoring", t, key)
+		return getTimeout()
+	}
+
+	log.Infof("%s applied: '%s'", key, parsed.String())
+	return parsed
+}
+
+// internalFolder returns the path of a folder of the okteto home that doesn't belong to a namespace
+func internalFolder(home, name string) string {
+	return filepath.Join(home, internalFolderName, name)
+}
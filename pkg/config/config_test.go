@@ -14,13 +14,20 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
 )
 
 func TestGetUserHomeDir(t *testing.T) {
+	ResetUserHomeDir()
 	home := GetUserHomeDir()
 	if home == "" {
 		t.Fatal("got an empty home value")
@@ -30,12 +37,21 @@ func TestGetUserHomeDir(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
 	defer func() {
 		os.RemoveAll(dir)
 		os.Unsetenv("OKTETO_HOME")
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+		ResetUserHomeDir()
 	}()
 
+	os.Unsetenv("XDG_CONFIG_HOME")
 	os.Setenv("OKTETO_HOME", dir)
+	if cached := GetUserHomeDir(); cached != home {
+		t.Fatalf("cached home changed from %s to %s", home, cached)
+	}
+
+	ResetUserHomeDir()
 	home = GetUserHomeDir()
 	if home != dir {
 		t.Fatalf("OKTETO_HOME override failed, got %s instead of %s", home, dir)
@@ -131,6 +147,104 @@ func TestGetOktetoHome(t *testing.T) {
 	}
 }
 
+func TestGetOktetoHomeRelative(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	// the temp folder can be a symlink, like in macOS
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OKTETO_FOLDER", "./.okteto")
+	expected := filepath.Join(dir, ".okteto")
+	got, err := GetOktetoHomeE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if !model.FileExists(expected) {
+		t.Errorf("%s wasn't created", expected)
+	}
+
+	if err := os.Chdir(os.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := OktetoHomePath(); got != expected {
+		t.Errorf("the okteto folder changed with the working directory: expected %s, got %s", expected, got)
+	}
+}
+
+func TestOktetoHomePath(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv("OKTETO_HOME")
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+		ResetUserHomeDir()
+	}()
+
+	os.Unsetenv("XDG_CONFIG_HOME")
+	home := filepath.Join(dir, "home")
+	os.Setenv("OKTETO_HOME", home)
+	ResetUserHomeDir()
+
+	if got, expected := OktetoHomePath(), filepath.Join(home, ".okteto"); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if model.FileExists(home) {
+		t.Fatal("OKTETO_HOME was created")
+	}
+
+	folder := filepath.Join(dir, "folder")
+	os.Setenv("OKTETO_FOLDER", folder)
+	if got := OktetoHomePath(); got != folder {
+		t.Errorf("got %s, expected %s", got, folder)
+	}
+
+	if model.FileExists(folder) {
+		t.Fatal("OKTETO_FOLDER was created")
+	}
+
+	if err := os.MkdirAll(folder, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetOktetoHome(); got != OktetoHomePath() {
+		t.Errorf("GetOktetoHome returned %s, OktetoHomePath returned %s", got, OktetoHomePath())
+	}
+}
+
 func TestGetDeploymentHome(t *testing.T) {
 	dir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -146,3 +260,822 @@ func TestGetDeploymentHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func Test_splitKubeConfigEnv(t *testing.T) {
+	var tests = []struct {
+		name     string
+		value    string
+		goos     string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			goos:     "linux",
+			expected: []string{},
+		},
+		{
+			name:     "single",
+			value:    "/home/okteto/.kube/config",
+			goos:     "linux",
+			expected: []string{"/home/okteto/.kube/config"},
+		},
+		{
+			name:     "multiple",
+			value:    "/home/okteto/.kube/config:/home/okteto/.kube/eks:/tmp/kubeconfig",
+			goos:     "darwin",
+			expected: []string{"/home/okteto/.kube/config", "/home/okteto/.kube/eks", "/tmp/kubeconfig"},
+		},
+		{
+			name:     "empty-segments",
+			value:    ":/home/okteto/.kube/config::/tmp/kubeconfig:",
+			goos:     "linux",
+			expected: []string{"/home/okteto/.kube/config", "/tmp/kubeconfig"},
+		},
+		{
+			name:     "windows",
+			value:    `c:\users\okteto\.kube\config;;c:\users\okteto\.kube\eks`,
+			goos:     "windows",
+			expected: []string{`c:\users\okteto\.kube\config`, `c:\users\okteto\.kube\eks`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitKubeConfigEnv(tt.value, tt.goos)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetKubeConfigFiles(t *testing.T) {
+	kubeconfig := os.Getenv("KUBECONFIG")
+	defer func() {
+		os.Setenv("KUBECONFIG", kubeconfig)
+		os.Unsetenv("OKTETO_KUBECONFIG")
+	}()
+
+	os.Unsetenv("OKTETO_KUBECONFIG")
+
+	original := serviceAccountTokenFile
+	serviceAccountTokenFile = filepath.Join(os.TempDir(), "okteto-token-does-not-exist")
+	defer func() { serviceAccountTokenFile = original }()
+
+	os.Setenv("KUBECONFIG", "")
+	got := GetKubeConfigFiles()
+	expected := []string{filepath.Join(GetUserHomeDir(), ".kube", "config")}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := filepath.Join(dir, "a")
+	b := filepath.Join(dir, "b")
+	for _, f := range []string{a, b} {
+		if err := ioutil.WriteFile(f, []byte{}, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	value := strings.Join([]string{a, b}, string(os.PathListSeparator))
+	os.Setenv("KUBECONFIG", value)
+	got = GetKubeConfigFiles()
+	expected = []string{a, b}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	if GetKubeConfigFile() != a {
+		t.Errorf("got %s, expected %s", GetKubeConfigFile(), a)
+	}
+
+	os.Setenv("OKTETO_KUBECONFIG", "/tmp/okteto")
+	got = GetKubeConfigFiles()
+	expected = []string{"/tmp/okteto"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OKTETO_KUBECONFIG", filepath.Join("kube", "config"))
+	got = GetKubeConfigFiles()
+	expected = []string{filepath.Join(wd, "kube", "config")}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestNormalizeKubeConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := filepath.Join(dir, "a")
+	b := filepath.Join(dir, "b")
+	for _, f := range []string{a, b} {
+		if err := ioutil.WriteFile(f, []byte{}, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	missing := filepath.Join(dir, "missing")
+	var tests = []struct {
+		name     string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "no-files",
+			files:    []string{},
+			expected: []string{},
+		},
+		{
+			name:     "duplicated",
+			files:    []string{a, b, a},
+			expected: []string{a, b},
+		},
+		{
+			name:     "unclean",
+			files:    []string{a, filepath.Join(dir, "..", filepath.Base(dir), "a"), dir + string(os.PathSeparator) + "." + string(os.PathSeparator) + "b"},
+			expected: []string{a, b},
+		},
+		{
+			name:     "missing",
+			files:    []string{missing, b},
+			expected: []string{b},
+		},
+		{
+			name:     "all-missing",
+			files:    []string{missing, filepath.Join(dir, "other")},
+			expected: []string{missing},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := strings.Join(tt.files, string(os.PathListSeparator))
+			if got := NormalizeKubeConfig(value); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func fakeInCluster(t *testing.T) func() {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(token, []byte("token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	original := serviceAccountTokenFile
+	host, hostOK := os.LookupEnv("KUBERNETES_SERVICE_HOST")
+	serviceAccountTokenFile = token
+	os.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+
+	return func() {
+		serviceAccountTokenFile = original
+		if hostOK {
+			os.Setenv("KUBERNETES_SERVICE_HOST", host)
+		} else {
+			os.Unsetenv("KUBERNETES_SERVICE_HOST")
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func TestIsRunningInCluster(t *testing.T) {
+	restore := fakeInCluster(t)
+	defer restore()
+
+	if !IsRunningInCluster() {
+		t.Error("expected to be running in cluster")
+	}
+
+	os.Unsetenv("KUBERNETES_SERVICE_HOST")
+	if IsRunningInCluster() {
+		t.Error("expected not to be running in cluster without KUBERNETES_SERVICE_HOST")
+	}
+
+	os.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	serviceAccountTokenFile = filepath.Join(os.TempDir(), "okteto-token-does-not-exist")
+	if IsRunningInCluster() {
+		t.Error("expected not to be running in cluster without a service account token")
+	}
+}
+
+func TestInClusterDefaults(t *testing.T) {
+	restore := fakeInCluster(t)
+	defer restore()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	home := os.Getenv("HOME")
+	okHome, okHomeOK := os.LookupEnv("OKTETO_HOME")
+	kubeconfig, kubeconfigOK := os.LookupEnv("KUBECONFIG")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Setenv("HOME", home)
+		if okHomeOK {
+			os.Setenv("OKTETO_HOME", okHome)
+		}
+		if kubeconfigOK {
+			os.Setenv("KUBECONFIG", kubeconfig)
+		}
+		ResetUserHomeDir()
+	}()
+
+	os.Unsetenv("HOME")
+	os.Unsetenv("OKTETO_HOME")
+	os.Unsetenv("OKTETO_KUBECONFIG")
+	os.Unsetenv("KUBECONFIG")
+	os.Setenv("OKTETO_FOLDER", dir)
+	ResetUserHomeDir()
+
+	if got := GetUserHomeDir(); got != os.TempDir() {
+		t.Errorf("got %s, expected %s", got, os.TempDir())
+	}
+
+	expected := []string{
+		filepath.Join(dir, inClusterKubeConfigName),
+		filepath.Join(os.TempDir(), ".kube", "config"),
+	}
+	if got := GetKubeConfigFiles(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestGetOktetoHomeE(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv("OKTETO_READONLY")
+	}()
+
+	folder := filepath.Join(dir, "okteto")
+	os.Setenv("OKTETO_FOLDER", folder)
+	os.Setenv("OKTETO_READONLY", "1")
+	if _, err := GetOktetoHomeE(); err == nil {
+		t.Fatal("expected error when OKTETO_FOLDER doesn't exist in read-only mode")
+	}
+
+	if _, err := GetNamespaceHomeE("ns"); err == nil {
+		t.Fatal("expected error when OKTETO_FOLDER doesn't exist in read-only mode")
+	}
+
+	if _, err := GetDeploymentHomeE("ns", "dp"); err == nil {
+		t.Fatal("expected error when OKTETO_FOLDER doesn't exist in read-only mode")
+	}
+
+	os.Unsetenv("OKTETO_READONLY")
+	if _, err := GetDeploymentHomeE("ns", "dp"); err != nil {
+		t.Fatalf("OKTETO_FOLDER wasn't created: %s", err)
+	}
+
+	if !model.FileExists(filepath.Join(folder, "ns", "dp")) {
+		t.Error("the deployment home wasn't created")
+	}
+}
+
+func Test_getOktetoFolder(t *testing.T) {
+	home, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		os.RemoveAll(home)
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+	}()
+
+	legacy := filepath.Join(home, ".okteto")
+	xdg := filepath.Join(home, ".config", "okteto")
+
+	os.Unsetenv("XDG_CONFIG_HOME")
+	if got := getOktetoFolder(home, "linux"); got != legacy {
+		t.Errorf("got %s, expected %s", got, legacy)
+	}
+
+	os.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	if got := getOktetoFolder(home, "linux"); got != xdg {
+		t.Errorf("got %s, expected %s", got, xdg)
+	}
+
+	if got := getOktetoFolder(home, "darwin"); got != legacy {
+		t.Errorf("got %s, expected %s", got, legacy)
+	}
+
+	if err := os.MkdirAll(legacy, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := getOktetoFolder(home, "linux"); got != legacy {
+		t.Errorf("got %s, expected %s", got, legacy)
+	}
+}
+
+func Test_parseFolderPermissions(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected os.FileMode
+	}{
+		{value: "0700", expected: 0700},
+		{value: "750", expected: 0750},
+		{value: "0777", expected: 0777},
+		{value: "1777", expected: 0700},
+		{value: "0800", expected: 0700},
+		{value: "rwx", expected: 0700},
+		{value: "", expected: 0700},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseFolderPermissions(tt.value); got != tt.expected {
+				t.Errorf("got %#o, expected %#o", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_getActionTimeout(t *testing.T) {
+	var tests = []struct {
+		name     string
+		action   string
+		env      map[string]string
+		expected time.Duration
+	}{
+		{
+			name:     "default",
+			action:   "up",
+			expected: GetTimeout(),
+		},
+		{
+			name:     "action",
+			action:   "up",
+			env:      map[string]string{"OKTETO_TIMEOUT_UP": "5m"},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "dashed-action",
+			action:   "namespace-list",
+			env:      map[string]string{"OKTETO_TIMEOUT_NAMESPACE_LIST": "10s"},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "other-action",
+			action:   "build",
+			env:      map[string]string{"OKTETO_TIMEOUT_UP": "5m"},
+			expected: GetTimeout(),
+		},
+		{
+			name:     "invalid",
+			action:   "up",
+			env:      map[string]string{"OKTETO_TIMEOUT_UP": "forever"},
+			expected: GetTimeout(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+
+			defer func() {
+				for k := range tt.env {
+					os.Unsetenv(k)
+				}
+			}()
+
+			if got := getActionTimeout(tt.action); got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	os.Setenv("OKTETO_TIMEOUT", "1m")
+	defer func() {
+		os.Unsetenv("OKTETO_TIMEOUT")
+		ResetTimeout()
+	}()
+
+	ResetTimeout()
+	if got := GetTimeout(); got != time.Minute {
+		t.Fatalf("got %s, expected OKTETO_TIMEOUT", got)
+	}
+
+	SetTimeout(2 * time.Minute)
+	if got := GetTimeout(); got != 2*time.Minute {
+		t.Errorf("got %s, expected the timeout to take precedence over OKTETO_TIMEOUT", got)
+	}
+
+	if got := GetTimeoutFor("up"); got != 2*time.Minute {
+		t.Errorf("got %s for up, expected the timeout", got)
+	}
+
+	SetTimeout(0)
+	SetTimeout(-time.Second)
+	if got := GetTimeout(); got != 2*time.Minute {
+		t.Errorf("got %s, expected invalid timeouts to be ignored", got)
+	}
+
+	ResetTimeout()
+	os.Unsetenv("OKTETO_TIMEOUT")
+	if got := GetTimeout(); got != 30*time.Second {
+		t.Errorf("got %s after reset, expected the default", got)
+	}
+}
+
+func TestSetManifestTimeout(t *testing.T) {
+	os.Unsetenv("OKTETO_TIMEOUT")
+	defer func() {
+		os.Unsetenv("OKTETO_TIMEOUT")
+		ResetTimeout()
+	}()
+
+	ResetTimeout()
+	if got := GetTimeout(); got != 30*time.Second {
+		t.Fatalf("got %s, expected the default", got)
+	}
+
+	SetManifestTimeout(time.Minute)
+	if got := GetTimeout(); got != time.Minute {
+		t.Errorf("got %s, expected the manifest timeout to replace the default", got)
+	}
+
+	if got := GetTimeoutFor("up"); got != time.Minute {
+		t.Errorf("got %s for up, expected the manifest timeout", got)
+	}
+
+	SetManifestTimeout(0)
+	if got := GetTimeout(); got != time.Minute {
+		t.Errorf("got %s, expected invalid timeouts to be ignored", got)
+	}
+
+	ResetTimeout()
+	os.Setenv("OKTETO_TIMEOUT", "2m")
+	SetManifestTimeout(time.Minute)
+	if got := GetTimeout(); got != 2*time.Minute {
+		t.Errorf("got %s, expected OKTETO_TIMEOUT to take precedence over the manifest timeout", got)
+	}
+
+	ResetTimeout()
+	os.Unsetenv("OKTETO_TIMEOUT")
+	SetTimeout(3 * time.Minute)
+	SetManifestTimeout(time.Minute)
+	if got := GetTimeout(); got != 3*time.Minute {
+		t.Errorf("got %s, expected the timeout to take precedence over the manifest timeout", got)
+	}
+}
+
+func TestGetUserHomeDirCreatesOktetoHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_HOME")
+		ResetUserHomeDir()
+	}()
+
+	home := filepath.Join(dir, "scratch", "home")
+	os.Setenv("OKTETO_HOME", home)
+	ResetUserHomeDir()
+
+	got, err := GetUserHomeDirE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != home {
+		t.Errorf("got %s, expected %s", got, home)
+	}
+
+	if info, err := os.Stat(home); err != nil || !info.IsDir() {
+		t.Errorf("%s wasn't created: %v", home, err)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(file, []byte("okteto"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OKTETO_HOME", file)
+	ResetUserHomeDir()
+	if _, err := GetUserHomeDirE(); err == nil {
+		t.Error("expected error when OKTETO_HOME is a file")
+	}
+}
+
+func TestListNamespaceHomes(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	GetDeploymentHome("ns-1", "dp")
+	GetNamespaceHome("ns-2")
+	if err := os.MkdirAll(filepath.Join(dir, ".warnings"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "okteto.log"), []byte("log"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"ns-1", "ns-2"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestRemoveDeploymentHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	d := GetDeploymentHome("ns", "dp")
+	if err := RemoveDeploymentHome("ns", "dp"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(d); !os.IsNotExist(err) {
+		t.Errorf("%s wasn't removed", d)
+	}
+
+	if err := RemoveDeploymentHome("ns", "dp"); err != nil {
+		t.Errorf("removing a missing folder should be a no-op: %s", err)
+	}
+
+	GetDeploymentHome("ns", "other")
+	for _, name := range []string{"", ".", "..", "../other", "../../etc"} {
+		if err := RemoveDeploymentHome("ns", name); err == nil {
+			t.Errorf("expected error for name '%s'", name)
+		}
+	}
+
+	if !model.FileExists(filepath.Join(dir, "ns", "other")) {
+		t.Error("a deployment outside of the target was removed")
+	}
+}
+
+func TestResolveBinaryPath(t *testing.T) {
+	got, err := ResolveBinaryPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !filepath.IsAbs(got) {
+		t.Errorf("expected an absolute path, got %s", got)
+	}
+
+	resolved, err := filepath.EvalSymlinks(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resolved != got {
+		t.Errorf("got %s, which is a symlink to %s", got, resolved)
+	}
+}
+
+func Test_parseTimeout(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected time.Duration
+		err      bool
+	}{
+		{value: "30s", expected: 30 * time.Second},
+		{value: "1m30s", expected: 90 * time.Second},
+		{value: "60", expected: 60 * time.Second},
+		{value: "0", expected: 0},
+		{value: "60x", err: true},
+		{value: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseTimeout(tt.value)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_findProjectOktetoFolder(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	home := filepath.Join(dir, "home")
+	project := filepath.Join(home, "src", "project")
+	nested := filepath.Join(project, "cmd", "api")
+	if err := os.MkdirAll(nested, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".okteto"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := findProjectOktetoFolder(nested, home); ok {
+		t.Fatal("the user's okteto folder shouldn't be considered a project folder")
+	}
+
+	expected := filepath.Join(project, ".okteto")
+	if err := os.MkdirAll(expected, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := findProjectOktetoFolder(nested, home)
+	if !ok {
+		t.Fatal("project folder not found")
+	}
+
+	if got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if _, ok := findProjectOktetoFolder(filepath.Join(dir, "other"), home); ok {
+		t.Error("found a project folder outside of the project")
+	}
+}
+
+func TestGetLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv("OKTETO_LOG_FILE")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	expected := filepath.Join(dir, "okteto.log")
+	if got := GetLogFile(); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	expected = filepath.Join(dir, "custom.log")
+	os.Setenv("OKTETO_LOG_FILE", expected)
+	if got := GetLogFile(); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
+
+func TestGetOktetoHomeReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_HOME")
+		os.Unsetenv("OKTETO_READONLY")
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+		ResetUserHomeDir()
+	}()
+
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Setenv("OKTETO_HOME", dir)
+	os.Setenv("OKTETO_READONLY", "1")
+	ResetUserHomeDir()
+
+	if _, err := GetOktetoHomeE(); err == nil {
+		t.Fatal("expected error in read-only mode when the okteto folder doesn't exist")
+	}
+
+	if model.FileExists(filepath.Join(dir, ".okteto")) {
+		t.Fatal("the okteto folder was created in read-only mode")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".okteto"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetOktetoHomeE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Join(dir, ".okteto"); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if _, err := GetDeploymentHomeE("ns", "dp"); err == nil {
+		t.Error("expected error in read-only mode when the deployment folder doesn't exist")
+	}
+}
+
+func TestGetFilesReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_HOME")
+		os.Unsetenv("OKTETO_READONLY")
+		os.Unsetenv("OKTETO_LOG_FILE")
+		os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+		ResetUserHomeDir()
+	}()
+
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Setenv("OKTETO_HOME", dir)
+	os.Setenv("OKTETO_READONLY", "1")
+	ResetUserHomeDir()
+
+	if _, err := GetLogFileE(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected %s, got %v", ErrReadOnly, err)
+	}
+
+	if _, err := GetDefaultsManifestFileE(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected %s, got %v", ErrReadOnly, err)
+	}
+
+	expected := filepath.Join(dir, "custom.log")
+	os.Setenv("OKTETO_LOG_FILE", expected)
+	got, err := GetLogFileE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
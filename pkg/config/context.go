@@ -0,0 +1,139 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	contextFolderName = "context"
+	contextFileName   = ".context.json"
+)
+
+type contextFile struct {
+	CurrentContext string `json:"current-context"`
+}
+
+// ContextHome returns the path of the folder of an okteto context, creating it if needed
+func (p *Paths) ContextHome(name string) (string, error) {
+	if name == "" || name == "." || name == ".." {
+		return "", newError(ErrInvalidName, nil, "'%s' is not a valid context name", name)
+	}
+
+	d := filepath.Join(internalFolder(p.Home, contextFolderName), escapeFolderName(name))
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
+
+// CurrentContext returns the okteto context selected with SetCurrentContext.
+// If none was selected, it returns the current context of the kubeconfig
+func (p *Paths) CurrentContext() (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(p.Home, contextFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read the current context: %w", err)
+	}
+
+	if err == nil {
+		c := &contextFile{}
+		if err := json.Unmarshal(b, c); err != nil {
+			return "", fmt.Errorf("failed to parse the current context: %w", err)
+		}
+
+		if c.CurrentContext != "" {
+			return c.CurrentContext, nil
+		}
+	}
+
+	for _, k := range p.KubeConfigFiles() {
+		cfg, err := clientcmd.LoadFromFile(k)
+		if err != nil {
+			log.Debugf("failed to load %s: %s", k, err)
+			continue
+		}
+
+		if cfg.CurrentContext != "" {
+			return cfg.CurrentContext, nil
+		}
+	}
+
+	return "", nil
+}
+
+// SetCurrentContext selects the okteto context used by default
+func (p *Paths) SetCurrentContext(name string) error {
+	b, err := json.Marshal(&contextFile{CurrentContext: name})
+	if err != nil {
+		return fmt.Errorf("failed to serialize the current context: %w", err)
+	}
+
+	return WriteFileAtomic(filepath.Join(p.Home, contextFileName), b, 0600)
+}
+
+// GetContextHome returns the path of the folder of an okteto context
+func GetContextHome(name string) string {
+	d, err := GetContextHomeE(name)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return d
+}
+
+// GetContextHomeE returns the path of the folder of an okteto context, or an error if it can't be created
+func GetContextHomeE(name string) (string, error) {
+	p, err := DefaultPaths()
+	if err != nil {
+		return "", err
+	}
+
+	return p.ContextHome(name)
+}
+
+// GetCurrentContext returns the okteto context in use, or an empty string if there is none
+func GetCurrentContext() string {
+	p, err := DefaultPaths()
+	if err != nil {
+		log.Infof("failed to get the current context: %s", err)
+		return ""
+	}
+
+	c, err := p.CurrentContext()
+	if err != nil {
+		log.Infof("failed to get the current context: %s", err)
+		return ""
+	}
+
+	return c
+}
+
+// SetCurrentContext selects the okteto context used by default
+func SetCurrentContext(name string) error {
+	p, err := DefaultPaths()
+	if err != nil {
+		return err
+	}
+
+	return p.SetCurrentContext(name)
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestContextHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := &Paths{Home: dir}
+	d, err := p.ContextHome("arn:aws:eks:us-east-1:1234:cluster/staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := filepath.Join(internalFolder(dir, contextFolderName), "arn%3Aaws%3Aeks%3Aus-east-1%3A1234%3Acluster%2Fstaging")
+	if d != expected {
+		t.Errorf("got %s, expected %s", d, expected)
+	}
+
+	if _, err := os.Stat(d); err != nil {
+		t.Errorf("%s wasn't created: %s", d, err)
+	}
+
+	for _, name := range []string{"", ".", ".."} {
+		if _, err := p.ContextHome(name); err == nil {
+			t.Errorf("expected error for context '%s'", name)
+		}
+	}
+
+	namespaces, err := p.ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(namespaces, []string{}) {
+		t.Errorf("the context folder was listed as a namespace: %v", namespaces)
+	}
+}
+
+func TestCurrentContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	kubeconfig := filepath.Join(dir, "config")
+	p := &Paths{Home: dir, KubeConfig: []string{filepath.Join(dir, "missing"), kubeconfig}}
+
+	got, err := p.CurrentContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "" {
+		t.Errorf("got '%s', expected no context", got)
+	}
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.CurrentContext = "staging"
+	if err := clientcmd.WriteToFile(*cfg, kubeconfig); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = p.CurrentContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "staging" {
+		t.Errorf("got '%s', expected the kubeconfig context", got)
+	}
+
+	if err := p.SetCurrentContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = p.CurrentContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "prod" {
+		t.Errorf("got '%s', expected 'prod'", got)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	okErrors "github.com/okteto/okteto/pkg/errors"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// CheckStatus is the result of a diagnostic check
+type CheckStatus string
+
+const (
+	// CheckPass means that the check didn't find any problem
+	CheckPass CheckStatus = "pass"
+
+	// CheckWarn means that okteto works, but the user should review the configuration
+	CheckWarn CheckStatus = "warn"
+
+	// CheckFail means that okteto won't work until the problem is fixed
+	CheckFail CheckStatus = "fail"
+)
+
+const (
+	// minSaneTimeout and maxSaneTimeout bound the timeouts that make sense for the requests to the cluster
+	minSaneTimeout = 5 * time.Second
+	maxSaneTimeout = time.Hour
+)
+
+// Check is the result of a diagnostic check of the okteto configuration
+type Check struct {
+	Name        string      `json:"name"`
+	Status      CheckStatus `json:"status"`
+	Message     string      `json:"message"`
+	Remediation string      `json:"remediation,omitempty"`
+}
+
+// RunDiagnostics checks the okteto home, the kubeconfig, the timeout and the disk space for common misconfigurations.
+// Failed checks are returned as checks, the error is only returned if ctx is done before the checks finish
+func RunDiagnostics(ctx context.Context) ([]Check, error) {
+	home, err := GetOktetoHomeE()
+	checks := []Check{checkHome(home, err)}
+	checks = append(checks, checkKubeConfig(ctx))
+
+	t, err := GetTimeoutE()
+	checks = append(checks, checkTimeout(t, err))
+
+	if home != "" {
+		checks = append(checks, checkDisk(home, MinDiskSpace))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+// HasFailedChecks returns true if any of the checks failed
+func HasFailedChecks(checks []Check) bool {
+	for _, c := range checks {
+		if c.Status == CheckFail {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkHome(home string, err error) Check {
+	c := Check{Name: "okteto home"}
+	if err != nil {
+		c.Status = CheckFail
+		c.Message = err.Error()
+		c.Remediation = "Set OKTETO_FOLDER to a folder you can write to"
+		return c
+	}
+
+	if IsReadOnly() {
+		c.Status = CheckWarn
+		c.Message = fmt.Sprintf("%s is used in read-only mode", home)
+		c.Remediation = "Unset OKTETO_READONLY unless okteto runs in a read-only filesystem"
+		return c
+	}
+
+	f, err := ioutil.TempFile(home, ".doctor")
+	if err != nil {
+		c.Status = CheckFail
+		c.Message = fmt.Sprintf("%s is not writable: %s", home, err)
+		c.Remediation = fmt.Sprintf("Fix the permissions of %s or set OKTETO_FOLDER to a folder you can write to", home)
+		return c
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	c.Status = CheckPass
+	c.Message = fmt.Sprintf("%s is writable", home)
+	return c
+}
+
+func checkKubeConfig(ctx context.Context) Check {
+	c := Check{Name: "kubeconfig"}
+	cfg, err := LoadKubeConfig(ctx)
+	if err != nil {
+		c.Status = CheckFail
+		c.Message = err.Error()
+		c.Remediation = "Run 'okteto namespace' to download your Kubernetes credentials, or set KUBECONFIG to your kubeconfig files"
+		var timeoutErr *KubeConfigTimeoutError
+		if errors.As(err, &timeoutErr) {
+			c.Remediation = "Check that your kubeconfig files are reachable, or increase OKTETO_TIMEOUT"
+		}
+		return c
+	}
+
+	return checkKubeContext(cfg, os.Getenv(kubeContextEnvVar))
+}
+
+func checkKubeContext(cfg *clientcmdapi.Config, override string) Check {
+	c := Check{Name: "kubeconfig"}
+	name, err := kubeContext(cfg, override)
+	if err != nil {
+		c.Status = CheckFail
+		c.Message = err.Error()
+		c.Remediation = fmt.Sprintf("Unset %s or set it to one of the contexts of your kubeconfig", kubeContextEnvVar)
+		return c
+	}
+
+	if name == "" {
+		if IsRunningInCluster() {
+			c.Status = CheckPass
+			c.Message = "using the service account of the pod"
+			return c
+		}
+
+		c.Status = CheckFail
+		c.Message = "your kubeconfig doesn't have a current context"
+		c.Remediation = "Run 'kubectl config use-context' to select the context of your cluster"
+		return c
+	}
+
+	if _, ok := cfg.Contexts[name]; !ok {
+		c.Status = CheckFail
+		c.Message = fmt.Sprintf("the current context '%s' is not defined in your kubeconfig", name)
+		c.Remediation = "Run 'kubectl config use-context' to select the context of your cluster"
+		return c
+	}
+
+	c.Status = CheckPass
+	c.Message = fmt.Sprintf("using the context '%s'", name)
+	return c
+}
+
+func checkTimeout(t time.Duration, err error) Check {
+	c := Check{Name: "timeout"}
+	if err != nil {
+		c.Status = CheckFail
+		c.Message = err.Error()
+		c.Remediation = "Set OKTETO_TIMEOUT to a duration like 30s or 2m"
+		return c
+	}
+
+	if t < minSaneTimeout || t > maxSaneTimeout {
+		c.Status = CheckWarn
+		c.Message = fmt.Sprintf("the timeout is %s", t)
+		c.Remediation = fmt.Sprintf("Set OKTETO_TIMEOUT to a duration between %s and %s", minSaneTimeout, maxSaneTimeout)
+		return c
+	}
+
+	c.Status = CheckPass
+	c.Message = fmt.Sprintf("the timeout is %s", t)
+	return c
+}
+
+func checkDisk(home string, minBytes uint64) Check {
+	c := Check{Name: "disk space"}
+	if err := checkDiskSpace(home, minBytes); err != nil {
+		c.Status = CheckFail
+		c.Message = err.Error()
+		if uErr, ok := err.(okErrors.UserError); ok {
+			c.Message = uErr.E.Error()
+			c.Remediation = uErr.Hint
+		}
+		return c
+	}
+
+	c.Status = CheckPass
+	c.Message = fmt.Sprintf("at least %s available in %s", formatBytes(minBytes), home)
+	return c
+}
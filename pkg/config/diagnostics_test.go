@@ -0,0 +1,137 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func Test_checkHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if c := checkHome(dir, nil); c.Status != CheckPass {
+		t.Errorf("expected pass, got %+v", c)
+	}
+
+	if c := checkHome("", fmt.Errorf("failed")); c.Status != CheckFail || c.Remediation == "" {
+		t.Errorf("expected fail with a remediation, got %+v", c)
+	}
+
+	if c := checkHome(filepath.Join(dir, "missing"), nil); c.Status != CheckFail {
+		t.Errorf("expected fail for a missing folder, got %+v", c)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) > 0 {
+		t.Errorf("the home check left %d files behind", len(files))
+	}
+}
+
+func Test_checkKubeContext(t *testing.T) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Contexts["dev"] = &clientcmdapi.Context{Cluster: "dev", AuthInfo: "dev"}
+
+	var tests = []struct {
+		name     string
+		current  string
+		override string
+		expected CheckStatus
+	}{
+		{name: "current", current: "dev", expected: CheckPass},
+		{name: "override", override: "dev", expected: CheckPass},
+		{name: "override-not-found", current: "dev", override: "prod", expected: CheckFail},
+		{name: "current-not-found", current: "prod", expected: CheckFail},
+		{name: "no-current", expected: CheckFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.CurrentContext = tt.current
+			c := checkKubeContext(cfg, tt.override)
+			if c.Status != tt.expected {
+				t.Errorf("expected %s, got %+v", tt.expected, c)
+			}
+
+			if c.Status == CheckFail && c.Remediation == "" {
+				t.Errorf("failed check without a remediation: %+v", c)
+			}
+		})
+	}
+}
+
+func Test_checkTimeout(t *testing.T) {
+	var tests = []struct {
+		name     string
+		timeout  time.Duration
+		err      error
+		expected CheckStatus
+	}{
+		{name: "default", timeout: 30 * time.Second, expected: CheckPass},
+		{name: "too-short", timeout: time.Second, expected: CheckWarn},
+		{name: "too-long", timeout: 2 * time.Hour, expected: CheckWarn},
+		{name: "invalid", err: fmt.Errorf("invalid timeout"), expected: CheckFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c := checkTimeout(tt.timeout, tt.err); c.Status != tt.expected {
+				t.Errorf("expected %s, got %+v", tt.expected, c)
+			}
+		})
+	}
+}
+
+func Test_checkDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if c := checkDisk(dir, 1); c.Status != CheckPass {
+		t.Errorf("expected pass, got %+v", c)
+	}
+
+	if c := checkDisk(dir, math.MaxUint64); c.Status != CheckFail || c.Remediation == "" {
+		t.Errorf("expected fail with a remediation, got %+v", c)
+	}
+}
+
+func TestHasFailedChecks(t *testing.T) {
+	checks := []Check{{Name: "a", Status: CheckPass}, {Name: "b", Status: CheckWarn}}
+	if HasFailedChecks(checks) {
+		t.Error("expected no failed checks")
+	}
+
+	checks = append(checks, Check{Name: "c", Status: CheckFail})
+	if !HasFailedChecks(checks) {
+		t.Error("expected failed checks")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// MinDiskSpace is the disk space required in the okteto home to run the synchronization service
+const MinDiskSpace = 100 * 1024 * 1024
+
+// CheckDiskSpace returns an error if the filesystem of the okteto home has less than minBytes available.
+// The okteto home doesn't need to exist, the closest existing parent folder is checked instead
+func CheckDiskSpace(minBytes uint64) error {
+	home, err := getOktetoHomePath()
+	if err != nil {
+		return err
+	}
+
+	return checkDiskSpace(home, minBytes)
+}
+
+func checkDiskSpace(path string, minBytes uint64) error {
+	path = closestExistingFolder(path)
+	available, err := availableDiskSpace(path)
+	if err != nil {
+		return fmt.Errorf("failed to get the available disk space of %s: %w", path, err)
+	}
+
+	log.Debugf("%s available in %s", formatBytes(available), path)
+	if available < minBytes {
+		return errors.UserError{
+			E:    fmt.Errorf("there isn't enough disk space available in %s: %s free, %s required", path, formatBytes(available), formatBytes(minBytes)),
+			Hint: "Free some disk space and try again",
+		}
+	}
+
+	return nil
+}
+
+func closestExistingFolder(path string) string {
+	path = filepath.Clean(path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+
+		path = parent
+	}
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+)
+
+func Test_checkDiskSpace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := checkDiskSpace(dir, 1); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if err := checkDiskSpace(filepath.Join(dir, "missing", "okteto"), 1); err != nil {
+		t.Errorf("unexpected error for a missing folder: %s", err)
+	}
+
+	err = checkDiskSpace(dir, math.MaxUint64)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if _, ok := err.(errors.UserError); !ok {
+		t.Errorf("expected a user error, got %T", err)
+	}
+}
+
+func Test_closestExistingFolder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if got := closestExistingFolder(filepath.Join(dir, "a", "b")); got != dir {
+		t.Errorf("got %s, expected %s", got, dir)
+	}
+
+	if got := closestExistingFolder(dir); got != dir {
+		t.Errorf("got %s, expected %s", got, dir)
+	}
+}
+
+func Test_formatBytes(t *testing.T) {
+	var tests = []struct {
+		bytes    uint64
+		expected string
+	}{
+		{bytes: 0, expected: "0B"},
+		{bytes: 1023, expected: "1023B"},
+		{bytes: 1024, expected: "1.0KiB"},
+		{bytes: 1536, expected: "1.5KiB"},
+		{bytes: 100 * 1024 * 1024, expected: "100.0MiB"},
+		{bytes: 5 * 1024 * 1024 * 1024, expected: "5.0GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.bytes); got != tt.expected {
+			t.Errorf("formatBytes(%d): got %s, expected %s", tt.bytes, got, tt.expected)
+		}
+	}
+}
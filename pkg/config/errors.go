@@ -0,0 +1,76 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrHomeNotFound is returned when the user home or the okteto home can't be found
+	ErrHomeNotFound = errors.New("home folder not found")
+
+	// ErrHomeNotWritable is returned when the folders and files of the okteto home can't be created
+	ErrHomeNotWritable = errors.New("home folder not writable")
+
+	// ErrReadOnly is returned when okteto must write a file while running in read-only mode
+	ErrReadOnly = errors.New("okteto is running in read-only mode")
+
+	// ErrKubeConfigNotFound is returned when none of the kubeconfig files exist
+	ErrKubeConfigNotFound = errors.New("kubeconfig not found")
+
+	// ErrKubeContextNotFound is returned when the kube context isn't defined in the kubeconfig
+	ErrKubeContextNotFound = errors.New("kube context not found")
+
+	// ErrInvalidTimeout is returned when a timeout can't be parsed
+	ErrInvalidTimeout = errors.New("invalid timeout")
+
+	// ErrInvalidName is returned when a namespace, deployment or context name can't be used as a folder name
+	ErrInvalidName = errors.New("invalid name")
+
+	// ErrChecksumMismatch is returned when a downloaded file doesn't match its expected checksum
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Error is the error returned by the functions of the config package.
+// errors.Is matches its Kind, one of the Err values of this package, and the underlying error, if any
+type Error struct {
+	Kind error
+	Msg  string
+	Err  error
+}
+
+// Error returns the error message
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("%s: %s", e.Msg, e.Err)
+}
+
+// Is returns true if target is the kind of the error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(kind, err error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Msg: fmt.Sprintf(format, args...), Err: err}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestErrorKinds(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(file, []byte("file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		env      map[string]string
+		run      func() error
+		expected error
+	}{
+		{
+			name: "okteto-folder-empty",
+			env:  map[string]string{"OKTETO_FOLDER": ""},
+			run: func() error {
+				_, err := GetOktetoHomeE()
+				return err
+			},
+			expected: ErrHomeNotFound,
+		},
+		{
+			name: "okteto-folder-missing-read-only",
+			env:  map[string]string{"OKTETO_FOLDER": filepath.Join(dir, "missing"), "OKTETO_READONLY": "1"},
+			run: func() error {
+				_, err := GetOktetoHomeE()
+				return err
+			},
+			expected: ErrReadOnly,
+		},
+		{
+			name: "not-writable",
+			run: func() error {
+				return ensureDir(filepath.Join(file, "okteto"))
+			},
+			expected: ErrHomeNotWritable,
+		},
+		{
+			name: "read-only",
+			env:  map[string]string{"OKTETO_READONLY": "1"},
+			run: func() error {
+				return ensureDir(filepath.Join(dir, "missing"))
+			},
+			expected: ErrReadOnly,
+		},
+		{
+			name: "invalid-timeout",
+			env:  map[string]string{"OKTETO_TIMEOUT": "forever"},
+			run: func() error {
+				_, err := GetTimeoutE()
+				return err
+			},
+			expected: ErrInvalidTimeout,
+		},
+		{
+			name: "invalid-name",
+			run: func() error {
+				return validatePathComponent("..")
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "context-not-found",
+			run: func() error {
+				cfg := clientcmdapi.NewConfig()
+				cfg.Contexts["okteto"] = &clientcmdapi.Context{}
+				_, err := kubeContext(cfg, "eks")
+				return err
+			},
+			expected: ErrKubeContextNotFound,
+		},
+		{
+			name: "kubeconfig-not-found",
+			env:  map[string]string{"KUBECONFIG": filepath.Join(dir, "missing-kubeconfig")},
+			run: func() error {
+				_, err := LoadKubeConfig(context.Background())
+				return err
+			},
+			expected: ErrKubeConfigNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer func() {
+				for k := range tt.env {
+					os.Unsetenv(k)
+				}
+				ResetTimeout()
+			}()
+
+			err := tt.run()
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected '%s', got '%v'", tt.expected, err)
+			}
+
+			if err.Error() == tt.expected.Error() {
+				t.Errorf("the message doesn't give any detail: %s", err)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(file, []byte("file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = ensureDir(filepath.Join(file, "okteto"))
+	if !errors.Is(err, ErrHomeNotWritable) {
+		t.Fatalf("expected ErrHomeNotWritable, got %v", err)
+	}
+
+	if !errors.Is(err, syscall.ENOTDIR) {
+		t.Errorf("the cause wasn't kept: %v", err)
+	}
+
+	if errors.Is(err, ErrHomeNotFound) {
+		t.Errorf("unexpected kind: %v", err)
+	}
+}
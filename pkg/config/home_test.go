@@ -0,0 +1,35 @@
+// +build !windows
+
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_homedirWindowsWithoutEnv(t *testing.T) {
+	for _, k := range []string{"HOME", "USERPROFILE", "HOMEPATH", "HOMEDRIVE"} {
+		v := os.Getenv(k)
+		os.Unsetenv(k)
+		defer os.Setenv(k, v)
+	}
+
+	os.Setenv("HOMEDRIVE", "H:")
+
+	if _, err := homedirWindows(); err == nil {
+		t.Fatal("expected an error when every method fails")
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// knownHomeFolder returns the profile folder of the current user using the windows API.
+// It works when the session doesn't define the usual env vars, like in services running on CI
+func knownHomeFolder() (string, error) {
+	home, knownErr := windows.KnownFolderPath(windows.FOLDERID_Profile, windows.KF_FLAG_DEFAULT)
+	if knownErr == nil && home != "" {
+		return home, nil
+	}
+
+	home, err := windows.GetCurrentProcessToken().GetUserProfileDirectory()
+	if err != nil {
+		return "", fmt.Errorf("SHGetKnownFolderPath failed: %v, GetUserProfileDirectory failed: %w", knownErr, err)
+	}
+
+	return home, nil
+}
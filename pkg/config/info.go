@@ -0,0 +1,120 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// sensitiveEnvKeywords are the parts of the names of env vars that hold credentials
+var sensitiveEnvKeywords = []string{"TOKEN", "SECRET", "PASSWORD", "KEY", "CREDENTIAL", "CONTENTS"}
+
+// Info is the effective configuration of okteto, meant to be attached to bug reports
+type Info struct {
+	Version        string            `json:"version"`
+	OS             string            `json:"os"`
+	Arch           string            `json:"arch"`
+	UserHome       string            `json:"userHome"`
+	OktetoHome     string            `json:"oktetoHome"`
+	KubeConfig     []string          `json:"kubeconfig"`
+	CurrentContext string            `json:"currentContext,omitempty"`
+	Timeout        string            `json:"timeout"`
+	ReadOnly       bool              `json:"readOnly"`
+	InCluster      bool              `json:"inCluster"`
+	Environment    map[string]string `json:"environment,omitempty"`
+	Errors         []string          `json:"errors,omitempty"`
+}
+
+// GetInfo returns the effective configuration, resolved with the same getters used by the rest of okteto.
+// Errors are reported in the result instead of failing, so the info is available when the configuration is broken
+func GetInfo() *Info {
+	info := &Info{
+		Version:     VersionString,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		Timeout:     GetTimeout().String(),
+		ReadOnly:    IsReadOnly(),
+		InCluster:   IsRunningInCluster(),
+		Environment: getInfoEnvironment(os.Environ()),
+	}
+
+	var err error
+	if info.UserHome, err = GetUserHomeDirE(); err != nil {
+		info.Errors = append(info.Errors, err.Error())
+	}
+
+	// displaying the configuration doesn't create the okteto folder
+	if info.OktetoHome, err = getOktetoHomePath(); err != nil {
+		info.Errors = append(info.Errors, err.Error())
+	}
+
+	if len(info.Errors) > 0 {
+		return info
+	}
+
+	info.KubeConfig = GetKubeConfigFiles()
+	if info.OktetoHome != "" {
+		p := &Paths{Home: info.OktetoHome, KubeConfig: info.KubeConfig}
+		if info.CurrentContext, err = p.CurrentContext(); err != nil {
+			info.Errors = append(info.Errors, err.Error())
+		}
+	}
+
+	return info
+}
+
+// JSON returns the info as indented json
+func (i *Info) JSON() ([]byte, error) {
+	return json.MarshalIndent(i, "", "  ")
+}
+
+// getInfoEnvironment returns the env vars that change the behavior of okteto, redacting the ones with credentials
+func getInfoEnvironment(environ []string) map[string]string {
+	result := map[string]string{}
+	for _, e := range environ {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		name := parts[0]
+		if !strings.HasPrefix(name, "OKTETO_") && name != "KUBECONFIG" {
+			continue
+		}
+
+		result[name] = redactEnv(name, parts[1])
+	}
+
+	return result
+}
+
+func redactEnv(name, value string) string {
+	if value == "" {
+		return value
+	}
+
+	upper := strings.ToUpper(name)
+	for _, k := range sensitiveEnvKeywords {
+		if strings.Contains(upper, k) {
+			return redactedValue
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_getInfoEnvironment(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"KUBECONFIG=/tmp/config",
+		"OKTETO_TIMEOUT=1m",
+		"OKTETO_TOKEN=abcdef",
+		"OKTETO_SSH_KEY=private",
+		"OKTETO_API_SECRET=",
+		"OKTETO_KUBECONFIG_CONTENTS=YXBpVmVyc2lvbjogdjE=",
+		"GITHUB_TOKEN=abcdef",
+	}
+
+	expected := map[string]string{
+		"KUBECONFIG":                 "/tmp/config",
+		"OKTETO_TIMEOUT":             "1m",
+		"OKTETO_TOKEN":               redactedValue,
+		"OKTETO_SSH_KEY":             redactedValue,
+		"OKTETO_API_SECRET":          "",
+		"OKTETO_KUBECONFIG_CONTENTS": redactedValue,
+	}
+
+	got := getInfoEnvironment(environ)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestGetInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, tokenOK := os.LookupEnv("OKTETO_TOKEN")
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		if tokenOK {
+			os.Setenv("OKTETO_TOKEN", token)
+		} else {
+			os.Unsetenv("OKTETO_TOKEN")
+		}
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	os.Setenv("OKTETO_TOKEN", "my-secret-token")
+
+	info := GetInfo()
+	if len(info.Errors) > 0 {
+		t.Fatalf("got unexpected errors: %v", info.Errors)
+	}
+
+	if info.OktetoHome != dir {
+		t.Errorf("got %s, expected %s", info.OktetoHome, dir)
+	}
+
+	if info.OS != runtime.GOOS {
+		t.Errorf("got %s, expected %s", info.OS, runtime.GOOS)
+	}
+
+	if !reflect.DeepEqual(info.KubeConfig, GetKubeConfigFiles()) {
+		t.Errorf("got %v, expected %v", info.KubeConfig, GetKubeConfigFiles())
+	}
+
+	if info.Timeout != GetTimeout().String() {
+		t.Errorf("got %s, expected %s", info.Timeout, GetTimeout())
+	}
+
+	b, err := info.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), "my-secret-token") {
+		t.Errorf("the token wasn't redacted: %s", string(b))
+	}
+
+	parsed := &Info{}
+	if err := json.Unmarshal(b, parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed, info) {
+		t.Errorf("got %+v, expected %+v", parsed, info)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// installMethodEnvVar overrides the install method detected from the path of the binary
+const installMethodEnvVar = "OKTETO_INSTALL_METHOD"
+
+const (
+	// InstallMethodBrew is used when okteto is installed with Homebrew
+	InstallMethodBrew = "brew"
+
+	// InstallMethodScript is used when okteto is installed with the install script
+	InstallMethodScript = "script"
+
+	// InstallMethodChoco is used when okteto is installed with Chocolatey
+	InstallMethodChoco = "choco"
+
+	// InstallMethodScoop is used when okteto is installed with Scoop
+	InstallMethodScoop = "scoop"
+
+	// InstallMethodBinary is used when the binary is downloaded manually, or the install method can't be detected
+	InstallMethodBinary = "binary"
+)
+
+// scriptInstallPath is where the install script copies the binary
+const scriptInstallPath = "/usr/local/bin/okteto"
+
+var installMethods = map[string]bool{
+	InstallMethodBrew:   true,
+	InstallMethodScript: true,
+	InstallMethodChoco:  true,
+	InstallMethodScoop:  true,
+	InstallMethodBinary: true,
+}
+
+// GetInstallMethod returns how okteto was installed, inferred from the path of the binary.
+// OKTETO_INSTALL_METHOD overrides it. It returns InstallMethodBinary if the install method can't be detected
+func GetInstallMethod() string {
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv(installMethodEnvVar))); v != "" {
+		if installMethods[v] {
+			return v
+		}
+
+		log.Infof("ignoring unknown %s '%s'", installMethodEnvVar, v)
+	}
+
+	p, err := ResolveBinaryPath()
+	if err != nil {
+		return InstallMethodBinary
+	}
+
+	return installMethod(p)
+}
+
+func installMethod(binary string) string {
+	p := strings.ToLower(strings.ReplaceAll(binary, `\`, "/"))
+	switch {
+	case strings.Contains(p, "/cellar/okteto/"), strings.Contains(p, "/homebrew/"), strings.Contains(p, "/linuxbrew/"):
+		return InstallMethodBrew
+	case strings.Contains(p, "/chocolatey/"):
+		return InstallMethodChoco
+	case strings.Contains(p, "/scoop/"):
+		return InstallMethodScoop
+	case p == scriptInstallPath:
+		return InstallMethodScript
+	default:
+		return InstallMethodBinary
+	}
+}
+
+// GetUpgradeCommand returns the command to upgrade okteto for the install method returned by GetInstallMethod
+func GetUpgradeCommand() string {
+	return upgradeCommand(GetInstallMethod(), runtime.GOOS)
+}
+
+func upgradeCommand(method, goos string) string {
+	switch method {
+	case InstallMethodBrew:
+		return "brew upgrade okteto"
+	case InstallMethodChoco:
+		return "choco upgrade okteto"
+	case InstallMethodScoop:
+		return "scoop update okteto"
+	case InstallMethodScript:
+		return "curl https://get.okteto.com -sSfL | sh"
+	}
+
+	if goos == "windows" {
+		return "https://github.com/okteto/okteto/releases/latest/download/okteto.exe"
+	}
+
+	return "curl https://get.okteto.com -sSfL | sh"
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_installMethod(t *testing.T) {
+	var tests = []struct {
+		name     string
+		binary   string
+		expected string
+	}{
+		{name: "brew-intel", binary: "/usr/local/Cellar/okteto/1.10.0/bin/okteto", expected: InstallMethodBrew},
+		{name: "brew-arm", binary: "/opt/homebrew/Cellar/okteto/1.10.0/bin/okteto", expected: InstallMethodBrew},
+		{name: "linuxbrew", binary: "/home/linuxbrew/.linuxbrew/Cellar/okteto/1.10.0/bin/okteto", expected: InstallMethodBrew},
+		{name: "script", binary: "/usr/local/bin/okteto", expected: InstallMethodScript},
+		{name: "choco", binary: `C:\ProgramData\chocolatey\lib\okteto\tools\okteto.exe`, expected: InstallMethodChoco},
+		{name: "scoop", binary: `C:\Users\cindy\scoop\apps\okteto\current\okteto.exe`, expected: InstallMethodScoop},
+		{name: "binary", binary: "/home/cindy/bin/okteto", expected: InstallMethodBinary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := installMethod(tt.binary); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetInstallMethod(t *testing.T) {
+	defer os.Unsetenv(installMethodEnvVar)
+
+	os.Setenv(installMethodEnvVar, "Brew")
+	if got := GetInstallMethod(); got != InstallMethodBrew {
+		t.Errorf("expected %s, got %s", InstallMethodBrew, got)
+	}
+
+	os.Setenv(installMethodEnvVar, "apt")
+	if got := GetInstallMethod(); !installMethods[got] {
+		t.Errorf("unexpected install method %s", got)
+	}
+}
+
+func Test_upgradeCommand(t *testing.T) {
+	var tests = []struct {
+		method   string
+		goos     string
+		expected string
+	}{
+		{method: InstallMethodBrew, goos: "darwin", expected: "brew upgrade okteto"},
+		{method: InstallMethodChoco, goos: "windows", expected: "choco upgrade okteto"},
+		{method: InstallMethodScoop, goos: "windows", expected: "scoop update okteto"},
+		{method: InstallMethodScript, goos: "linux", expected: "curl https://get.okteto.com -sSfL | sh"},
+		{method: InstallMethodBinary, goos: "linux", expected: "curl https://get.okteto.com -sSfL | sh"},
+		{method: InstallMethodBinary, goos: "windows", expected: "https://github.com/okteto/okteto/releases/latest/download/okteto.exe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+"-"+tt.goos, func(t *testing.T) {
+			if got := upgradeCommand(tt.method, tt.goos); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	okErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// kubeContextEnvVar pins the kube context used by okteto without changing the current context of the kubeconfig
+	kubeContextEnvVar = "OKTETO_CONTEXT"
+
+	// kubeConfigContentsEnvVar holds a base64 encoded kubeconfig, used when the kubeconfig can't be stored in a file, like in CI
+	kubeConfigContentsEnvVar = "OKTETO_KUBECONFIG_CONTENTS"
+
+	// kubeConfigStandaloneEnvVar makes okteto write its context to a kubeconfig of its own, instead of merging it
+	kubeConfigStandaloneEnvVar = "OKTETO_KUBECONFIG_STANDALONE"
+)
+
+// KubeConfigTimeoutError is returned by LoadKubeConfig when the kubeconfig files can't be loaded before the deadline
+type KubeConfigTimeoutError struct {
+	Files []string
+	Err   error
+}
+
+// Error returns the error message
+func (e *KubeConfigTimeoutError) Error() string {
+	return fmt.Sprintf("timed out loading the kubeconfig from %s, check that the files are reachable", strings.Join(e.Files, ", "))
+}
+
+// Unwrap returns the error of the context
+func (e *KubeConfigTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// loadKubeConfigFiles merges files like kubectl does: the first file that sets a value wins
+var loadKubeConfigFiles = func(files []string) (*clientcmdapi.Config, error) {
+	rules := &clientcmd.ClientConfigLoadingRules{Precedence: files}
+	return rules.Load()
+}
+
+// LoadKubeConfig loads and merges the files returned by GetKubeConfigFiles.
+// If ctx has no deadline, loading is limited by GetTimeout, so a slow network mount can't block okteto forever
+// It returns an ErrKubeConfigNotFound error if none of the files exist, unless okteto runs in a cluster with its service account
+func LoadKubeConfig(ctx context.Context) (*clientcmdapi.Config, error) {
+	files := GetKubeConfigFiles()
+	cfg, err := loadKubeConfig(ctx, files, GetTimeout())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Contexts) == 0 && len(cfg.Clusters) == 0 && !IsRunningInCluster() && !anyFileExists(files) {
+		return nil, newError(ErrKubeConfigNotFound, nil, "kubeconfig not found in %s", strings.Join(files, ", "))
+	}
+
+	return cfg, nil
+}
+
+func anyFileExists(files []string) bool {
+	for _, f := range files {
+		if _, err := os.Stat(f); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func loadKubeConfig(ctx context.Context, files []string, timeout time.Duration) (*clientcmdapi.Config, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		cfg *clientcmdapi.Config
+		err error
+	}
+
+	// the goroutine can't be interrupted, it finishes in the background if the deadline is exceeded
+	ch := make(chan result, 1)
+	go func() {
+		cfg, err := loadKubeConfigFiles(files)
+		ch <- result{cfg: cfg, err: err}
+	}()
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to load the kubeconfig: %w", r.err)
+		}
+		return r.cfg, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, &KubeConfigTimeoutError{Files: files, Err: ctx.Err()}
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// GetKubeContext returns the kube context set in OKTETO_CONTEXT, or the current context of the kubeconfig
+func GetKubeContext() string {
+	c, err := GetKubeContextE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return c
+}
+
+// GetKubeContextE returns the kube context set in OKTETO_CONTEXT, or the current context of the kubeconfig.
+// It returns an error if OKTETO_CONTEXT isn't a context of the kubeconfig. The kubeconfig is never modified
+func GetKubeContextE() (string, error) {
+	cfg, err := LoadKubeConfig(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	return kubeContext(cfg, os.Getenv(kubeContextEnvVar))
+}
+
+func kubeContext(cfg *clientcmdapi.Config, override string) (string, error) {
+	if override == "" {
+		return cfg.CurrentContext, nil
+	}
+
+	if _, ok := cfg.Contexts[override]; ok {
+		return override, nil
+	}
+
+	if len(cfg.Contexts) == 0 {
+		return "", newError(ErrKubeContextNotFound, nil, "%s is '%s', but your kubeconfig doesn't define any context", kubeContextEnvVar, override)
+	}
+
+	names := make([]string, 0, len(cfg.Contexts))
+	for n := range cfg.Contexts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return "", newError(ErrKubeContextNotFound, nil, "%s is '%s', but it's not a context of your kubeconfig. Available contexts: %s", kubeContextEnvVar, override, strings.Join(names, ", "))
+}
+
+// MergeIntoKubeConfig upserts a context, and the cluster and user it references, into the kubeconfig file at path.
+// Entries with other names are preserved. Existing entries only get the fields okteto owns updated,
+// so the fields the user added to them are kept. The current context is only changed if switchContext is true
+func MergeIntoKubeConfig(path, contextName string, cluster *clientcmdapi.Cluster, context *clientcmdapi.Context, user *clientcmdapi.AuthInfo, switchContext bool) error {
+	if contextName == "" || context == nil || context.Cluster == "" || context.AuthInfo == "" {
+		return fmt.Errorf("the context must reference a cluster and a user")
+	}
+
+	cfg, err := loadKubeConfigOrEmpty(path)
+	if err != nil {
+		return err
+	}
+
+	mergeKubeConfigCluster(cfg, context.Cluster, cluster)
+	mergeKubeConfigUser(cfg, context.AuthInfo, user)
+	mergeKubeConfigContext(cfg, contextName, context)
+	if switchContext || cfg.CurrentContext == "" {
+		cfg.CurrentContext = contextName
+	}
+
+	return writeKubeConfig(path, cfg)
+}
+
+// mergeKubeConfigCluster updates the server and the certificate authority of the cluster called name.
+// The certificate authority data isn't set if the user overrides it with a file or skips the TLS verification
+func mergeKubeConfigCluster(cfg *clientcmdapi.Config, name string, cluster *clientcmdapi.Cluster) {
+	existing, ok := cfg.Clusters[name]
+	if !ok || existing == nil {
+		cfg.Clusters[name] = cluster
+		return
+	}
+
+	existing.Server = cluster.Server
+	if existing.CertificateAuthority == "" && !existing.InsecureSkipTLSVerify {
+		existing.CertificateAuthorityData = cluster.CertificateAuthorityData
+	}
+}
+
+// mergeKubeConfigUser updates the token of the user called name
+func mergeKubeConfigUser(cfg *clientcmdapi.Config, name string, user *clientcmdapi.AuthInfo) {
+	existing, ok := cfg.AuthInfos[name]
+	if !ok || existing == nil {
+		cfg.AuthInfos[name] = user
+		return
+	}
+
+	existing.Token = user.Token
+}
+
+// mergeKubeConfigContext updates the cluster, user and namespace of the context called name
+func mergeKubeConfigContext(cfg *clientcmdapi.Config, name string, context *clientcmdapi.Context) {
+	existing, ok := cfg.Contexts[name]
+	if !ok || existing == nil {
+		cfg.Contexts[name] = context
+		return
+	}
+
+	existing.Cluster = context.Cluster
+	existing.AuthInfo = context.AuthInfo
+	existing.Namespace = context.Namespace
+}
+
+// IsKubeConfigStandalone returns true if OKTETO_KUBECONFIG_STANDALONE is set,
+// meaning that okteto must write its context to a standalone kubeconfig instead of merging it
+func IsKubeConfigStandalone() bool {
+	return os.Getenv(kubeConfigStandaloneEnvVar) == "1"
+}
+
+// WriteStandaloneKubeConfig writes a kubeconfig with only a context, and the cluster and user it references, to path.
+// The file is overwritten instead of merged. Unless force is true, it returns an error if the file has other contexts,
+// so a kubeconfig that okteto doesn't manage is never lost
+func WriteStandaloneKubeConfig(path, contextName string, cluster *clientcmdapi.Cluster, context *clientcmdapi.Context, user *clientcmdapi.AuthInfo, force bool) error {
+	if contextName == "" || context == nil || context.Cluster == "" || context.AuthInfo == "" {
+		return fmt.Errorf("the context must reference a cluster and a user")
+	}
+
+	if !force {
+		existing, err := loadKubeConfigOrEmpty(path)
+		if err != nil {
+			return err
+		}
+
+		others := []string{}
+		for name := range existing.Contexts {
+			if name != contextName {
+				others = append(others, name)
+			}
+		}
+
+		if len(others) > 0 {
+			sort.Strings(others)
+			return okErrors.UserError{
+				E:    fmt.Errorf("%s has contexts that okteto doesn't manage: %s", path, strings.Join(others, ", ")),
+				Hint: "Set KUBECONFIG to a different file, or use the --force flag to overwrite it",
+			}
+		}
+	}
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[context.Cluster] = cluster
+	cfg.AuthInfos[context.AuthInfo] = user
+	cfg.Contexts[contextName] = context
+	cfg.CurrentContext = contextName
+	return writeKubeConfig(path, cfg)
+}
+
+// writeKubeConfig replaces the kubeconfig at path with cfg.
+// Symlinks are resolved first, so the atomic rename updates their target instead of replacing the link
+func writeKubeConfig(path string, cfg *clientcmdapi.Config) error {
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	switch {
+	case err == nil:
+		path = resolved
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	return WriteFileAtomic(path, data, 0600)
+}
+
+func loadKubeConfigOrEmpty(path string) (*clientcmdapi.Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return clientcmdapi.NewConfig(), nil
+		}
+
+		return nil, err
+	}
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// LoadKubeConfigContents writes the kubeconfig defined in OKTETO_KUBECONFIG_CONTENTS to the temp folder
+// and sets OKTETO_KUBECONFIG to it, so it's used as the effective kubeconfig.
+// The returned function removes the file, call it with defer
+func LoadKubeConfigContents() (func(), error) {
+	v := os.Getenv(kubeConfigContentsEnvVar)
+	if v == "" {
+		return func() {}, nil
+	}
+
+	path, err := writeKubeConfigContents(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Setenv("OKTETO_KUBECONFIG", path); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to set OKTETO_KUBECONFIG: %w", err)
+	}
+
+	log.Infof("using the kubeconfig defined in %s", kubeConfigContentsEnvVar)
+	return func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Infof("failed to remove %s: %s", path, err)
+		}
+	}, nil
+}
+
+// writeKubeConfigContents validates the base64 encoded kubeconfig and writes it to a temp file only the user can read
+func writeKubeConfigContents(encoded string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", fmt.Errorf("%s is not valid base64: %w", kubeConfigContentsEnvVar, err)
+	}
+
+	cfg, err := clientcmd.Load(b)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a valid kubeconfig: %w", kubeConfigContentsEnvVar, err)
+	}
+
+	if len(cfg.Clusters) == 0 {
+		return "", fmt.Errorf("%s is not a valid kubeconfig: no clusters are defined", kubeConfigContentsEnvVar)
+	}
+
+	f, err := NewTempFile("kubeconfig")
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeKubeConfigFile(f, b); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+func writeKubeConfigFile(f *os.File, b []byte) error {
+	defer f.Close()
+	if err := f.Chmod(0600); err != nil {
+		return fmt.Errorf("failed to set the permissions of %s: %w", f.Name(), err)
+	}
+
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Name(), err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,536 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	okErrors "github.com/okteto/okteto/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestMergeIntoKubeConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".kube", "config")
+	existing := clientcmdapi.NewConfig()
+	existing.Clusters["eks"] = &clientcmdapi.Cluster{Server: "https://eks"}
+	existing.AuthInfos["eks-user"] = &clientcmdapi.AuthInfo{Token: "eks-token"}
+	existing.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks-user"}
+	existing.CurrentContext = "eks"
+	if err := clientcmd.WriteToFile(*existing, path); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := &clientcmdapi.Cluster{Server: "https://okteto"}
+	user := &clientcmdapi.AuthInfo{Token: "okteto-token"}
+	context := &clientcmdapi.Context{Cluster: "cloud_okteto_com", AuthInfo: "user", Namespace: "ns"}
+	if err := MergeIntoKubeConfig(path, "cloud_okteto_com", cluster, context, user, false); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Contexts) != 2 || len(cfg.Clusters) != 2 || len(cfg.AuthInfos) != 2 {
+		t.Fatalf("unexpected entries: %+v", cfg)
+	}
+
+	if cfg.CurrentContext != "eks" {
+		t.Errorf("current context changed to %s", cfg.CurrentContext)
+	}
+
+	context.Namespace = "ns-2"
+	if err := MergeIntoKubeConfig(path, "cloud_okteto_com", cluster, context, user, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Contexts) != 2 {
+		t.Errorf("expected 2 contexts, got %d", len(cfg.Contexts))
+	}
+
+	if cfg.CurrentContext != "cloud_okteto_com" {
+		t.Errorf("current context is %s", cfg.CurrentContext)
+	}
+
+	if cfg.Contexts["cloud_okteto_com"].Namespace != "ns-2" {
+		t.Errorf("context wasn't updated: %+v", cfg.Contexts["cloud_okteto_com"])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("got permissions %#o", info.Mode().Perm())
+	}
+
+	if err := MergeIntoKubeConfig(path, "invalid", cluster, &clientcmdapi.Context{}, user, false); err == nil {
+		t.Error("expected error for a context without cluster and user")
+	}
+}
+
+func TestMergeIntoKubeConfigExistingEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	existing := clientcmdapi.NewConfig()
+	existing.Clusters["cloud_okteto_com"] = &clientcmdapi.Cluster{Server: "https://old", ProxyURL: "http://proxy:3128", CertificateAuthority: "/etc/ca.crt"}
+	existing.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "old-token", ClientCertificate: "/etc/client.crt"}
+	existing.Contexts["cloud_okteto_com"] = &clientcmdapi.Context{Cluster: "cloud_okteto_com", AuthInfo: "user", Namespace: "old"}
+	if err := clientcmd.WriteToFile(*existing, path); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := &clientcmdapi.Cluster{Server: "https://okteto", CertificateAuthorityData: []byte("ca")}
+	user := &clientcmdapi.AuthInfo{Token: "okteto-token"}
+	context := &clientcmdapi.Context{Cluster: "cloud_okteto_com", AuthInfo: "user", Namespace: "ns"}
+	if err := MergeIntoKubeConfig(path, "cloud_okteto_com", cluster, context, user, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := cfg.Clusters["cloud_okteto_com"]
+	if c.Server != "https://okteto" {
+		t.Errorf("the server wasn't updated: %+v", c)
+	}
+	if c.ProxyURL != "http://proxy:3128" || c.CertificateAuthority != "/etc/ca.crt" {
+		t.Errorf("the fields of the user were dropped: %+v", c)
+	}
+	if len(c.CertificateAuthorityData) > 0 {
+		t.Errorf("the certificate authority data was set with a certificate authority file: %+v", c)
+	}
+
+	u := cfg.AuthInfos["user"]
+	if u.Token != "okteto-token" {
+		t.Errorf("the token wasn't updated: %+v", u)
+	}
+	if u.ClientCertificate != "/etc/client.crt" {
+		t.Errorf("the fields of the user were dropped: %+v", u)
+	}
+
+	if cfg.Contexts["cloud_okteto_com"].Namespace != "ns" {
+		t.Errorf("the context wasn't updated: %+v", cfg.Contexts["cloud_okteto_com"])
+	}
+}
+
+func TestMergeIntoKubeConfigSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "dotfiles", "kubeconfig")
+	existing := clientcmdapi.NewConfig()
+	existing.Clusters["eks"] = &clientcmdapi.Cluster{Server: "https://eks"}
+	existing.AuthInfos["eks-user"] = &clientcmdapi.AuthInfo{Token: "eks-token"}
+	existing.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks-user"}
+	if err := clientcmd.WriteToFile(*existing, target); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "config")
+	if err := os.Symlink(target, path); err != nil {
+		t.Fatal(err)
+	}
+
+	context := &clientcmdapi.Context{Cluster: "cloud_okteto_com", AuthInfo: "user", Namespace: "ns"}
+	if err := MergeIntoKubeConfig(path, "cloud_okteto_com", &clientcmdapi.Cluster{Server: "https://okteto"}, context, &clientcmdapi.AuthInfo{Token: "okteto-token"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatal("the symlink was replaced by a regular file")
+	}
+
+	cfg, err := clientcmd.LoadFromFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Contexts) != 2 {
+		t.Errorf("the target of the symlink wasn't updated: %+v", cfg.Contexts)
+	}
+}
+
+func TestWriteStandaloneKubeConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "kubeconfig")
+	cluster := &clientcmdapi.Cluster{Server: "https://okteto"}
+	user := &clientcmdapi.AuthInfo{Token: "okteto-token"}
+	context := &clientcmdapi.Context{Cluster: "cloud_okteto_com", AuthInfo: "user", Namespace: "ns"}
+	if err := WriteStandaloneKubeConfig(path, "cloud_okteto_com", cluster, context, user, false); err != nil {
+		t.Fatal(err)
+	}
+
+	context.Namespace = "ns-2"
+	if err := WriteStandaloneKubeConfig(path, "cloud_okteto_com", cluster, context, user, false); err != nil {
+		t.Fatalf("failed to overwrite the standalone kubeconfig: %s", err)
+	}
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Contexts) != 1 || len(cfg.Clusters) != 1 || len(cfg.AuthInfos) != 1 {
+		t.Fatalf("unexpected entries: %+v", cfg)
+	}
+
+	if cfg.CurrentContext != "cloud_okteto_com" || cfg.Contexts["cloud_okteto_com"].Namespace != "ns-2" {
+		t.Errorf("unexpected context: %+v", cfg)
+	}
+
+	existing := clientcmdapi.NewConfig()
+	existing.Clusters["eks"] = &clientcmdapi.Cluster{Server: "https://eks"}
+	existing.AuthInfos["eks-user"] = &clientcmdapi.AuthInfo{Token: "eks-token"}
+	existing.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks-user"}
+	if err := clientcmd.WriteToFile(*existing, path); err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteStandaloneKubeConfig(path, "cloud_okteto_com", cluster, context, user, false)
+	if _, ok := err.(okErrors.UserError); !ok {
+		t.Fatalf("expected a user error, got %v", err)
+	}
+
+	cfg, err = clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cfg.Contexts["eks"]; !ok {
+		t.Fatal("the kubeconfig was overwritten")
+	}
+
+	if err := WriteStandaloneKubeConfig(path, "cloud_okteto_com", cluster, context, user, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Contexts) != 1 || cfg.CurrentContext != "cloud_okteto_com" {
+		t.Errorf("the kubeconfig wasn't overwritten: %+v", cfg)
+	}
+}
+
+func TestLoadKubeConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	first := clientcmdapi.NewConfig()
+	first.Contexts["okteto"] = &clientcmdapi.Context{Cluster: "okteto", AuthInfo: "okteto"}
+	first.CurrentContext = "okteto"
+
+	second := clientcmdapi.NewConfig()
+	second.Contexts["okteto"] = &clientcmdapi.Context{Cluster: "other", AuthInfo: "other"}
+	second.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks"}
+	second.CurrentContext = "eks"
+
+	files := []string{filepath.Join(dir, "first"), filepath.Join(dir, "second"), filepath.Join(dir, "missing")}
+	for i, cfg := range []*clientcmdapi.Config{first, second} {
+		if err := clientcmd.WriteToFile(*cfg, files[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := loadKubeConfig(context.Background(), files, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.CurrentContext != "okteto" {
+		t.Errorf("got current context %s, expected okteto", cfg.CurrentContext)
+	}
+
+	if cfg.Contexts["okteto"].Cluster != "okteto" {
+		t.Errorf("the first file didn't take precedence: %+v", cfg.Contexts["okteto"])
+	}
+
+	if _, ok := cfg.Contexts["eks"]; !ok {
+		t.Error("the contexts of the second file weren't merged")
+	}
+}
+
+func TestLoadKubeConfigTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	load := loadKubeConfigFiles
+	loadKubeConfigFiles = func(files []string) (*clientcmdapi.Config, error) {
+		<-release
+		return clientcmdapi.NewConfig(), nil
+	}
+	defer func() { loadKubeConfigFiles = load }()
+
+	_, err := loadKubeConfig(context.Background(), []string{"/mnt/slow/config"}, 10*time.Millisecond)
+	var timeoutErr *KubeConfigTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("got %v, expected a timeout error", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("%v doesn't wrap the deadline error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := loadKubeConfig(ctx, []string{"/mnt/slow/config"}, time.Minute); err != context.Canceled {
+		t.Errorf("got %v, expected the context to be canceled", err)
+	}
+}
+
+func Test_kubeContext(t *testing.T) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Contexts["okteto"] = &clientcmdapi.Context{Cluster: "okteto", AuthInfo: "okteto"}
+	cfg.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks"}
+	cfg.CurrentContext = "okteto"
+
+	var tests = []struct {
+		name      string
+		cfg       *clientcmdapi.Config
+		override  string
+		expected  string
+		expectErr bool
+	}{
+		{name: "current-context", cfg: cfg, expected: "okteto"},
+		{name: "override", cfg: cfg, override: "eks", expected: "eks"},
+		{name: "missing-override", cfg: cfg, override: "gke", expectErr: true},
+		{name: "empty-kubeconfig", cfg: clientcmdapi.NewConfig(), override: "gke", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kubeContext(tt.cfg, tt.override)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetKubeContextE(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_CONTEXT")
+		os.Unsetenv("KUBECONFIG")
+	}()
+
+	path := filepath.Join(dir, "config")
+	cfg := clientcmdapi.NewConfig()
+	cfg.Contexts["okteto"] = &clientcmdapi.Context{Cluster: "okteto", AuthInfo: "okteto"}
+	cfg.Contexts["eks"] = &clientcmdapi.Context{Cluster: "eks", AuthInfo: "eks"}
+	cfg.CurrentContext = "okteto"
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("KUBECONFIG", path)
+	os.Setenv("OKTETO_CONTEXT", "eks")
+
+	got, err := GetKubeContextE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "eks" {
+		t.Errorf("got %s, expected eks", got)
+	}
+
+	saved, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.CurrentContext != "okteto" {
+		t.Errorf("the current context of the kubeconfig was changed to %s", saved.CurrentContext)
+	}
+}
+
+func TestLoadKubeConfigContents(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv("OKTETO_KUBECONFIG")
+		os.Unsetenv(kubeConfigContentsEnvVar)
+	}()
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["okteto"] = &clientcmdapi.Cluster{Server: "https://okteto.example.com"}
+	b, err := clientcmd.Write(*cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	os.Setenv(kubeConfigContentsEnvVar, base64.StdEncoding.EncodeToString(b))
+
+	remove, err := LoadKubeConfigContents()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("OKTETO_KUBECONFIG")
+	if filepath.Dir(path) != internalFolder(dir, tempFolderName) {
+		t.Fatalf("%s wasn't created in the temp folder", path)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("got permissions %#o, expected 0600", info.Mode().Perm())
+		}
+	}
+
+	loaded, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := loaded.Clusters["okteto"]; !ok {
+		t.Errorf("the cluster wasn't written to %s", path)
+	}
+
+	remove()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s wasn't removed", path)
+	}
+}
+
+func TestLoadKubeConfigContentsInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		os.Unsetenv(kubeConfigContentsEnvVar)
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	var tests = []struct {
+		name     string
+		contents string
+	}{
+		{name: "not-base64", contents: "not base64!"},
+		{name: "not-yaml", contents: base64.StdEncoding.EncodeToString([]byte("{clusters: ["))},
+		{name: "no-clusters", contents: base64.StdEncoding.EncodeToString([]byte("{}"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(kubeConfigContentsEnvVar, tt.contents)
+			if _, err := LoadKubeConfigContents(); err == nil {
+				t.Error("expected an error")
+			}
+
+			if v := os.Getenv("OKTETO_KUBECONFIG"); v != "" {
+				t.Errorf("OKTETO_KUBECONFIG was set to %s", v)
+			}
+		})
+	}
+}
+
+func TestLoadKubeConfigContentsNotSet(t *testing.T) {
+	os.Unsetenv(kubeConfigContentsEnvVar)
+	remove, err := LoadKubeConfigContents()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remove()
+}
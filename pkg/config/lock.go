@@ -0,0 +1,88 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	ps "github.com/mitchellh/go-ps"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const lockFileName = "okteto.lock"
+
+// processExists returns if there is a running process with the pid
+var processExists = func(pid int) bool {
+	p, err := ps.FindProcess(pid)
+	return err == nil && p != nil
+}
+
+// AcquireDeploymentLock makes sure only one okteto process is active for a deployment.
+// The lock file stores the pid of the holder, so locks of processes that no longer exist are reclaimed.
+// It's read and written holding the state lock of the deployment, so two processes can't both get it.
+// The returned function releases the lock, call it with defer
+func AcquireDeploymentLock(namespace, name string) (func(), error) {
+	d, err := GetDeploymentHomeE(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(d, lockFileName)
+	err = withStateLock(d, func() error {
+		pid, err := readLockPID(path)
+		if err == nil && pid != os.Getpid() && processExists(pid) {
+			return fmt.Errorf("okteto is already active for %s/%s (pid %d)", namespace, name, pid)
+		}
+
+		if err == nil {
+			log.Infof("reclaiming stale lock %s", path)
+		}
+
+		return WriteFileAtomic(path, []byte(strconv.Itoa(os.Getpid())), 0600)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			err := withStateLock(d, func() error {
+				if pid, err := readLockPID(path); err != nil || pid != os.Getpid() {
+					return nil
+				}
+
+				return os.Remove(path)
+			})
+			if err != nil {
+				log.Infof("failed to remove %s: %s", path, err)
+			}
+		})
+	}, nil
+}
+
+func readLockPID(path string) (int, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
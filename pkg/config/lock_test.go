@@ -0,0 +1,84 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func TestAcquireDeploymentLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	lock := filepath.Join(dir, "ns", "dp", lockFileName)
+
+	release, err := AcquireDeploymentLock("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !model.FileExists(lock) {
+		t.Fatalf("%s wasn't created", lock)
+	}
+
+	release()
+	release()
+	if model.FileExists(lock) {
+		t.Fatalf("%s wasn't removed", lock)
+	}
+
+	if err := ioutil.WriteFile(lock, []byte("12345"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	exists := processExists
+	defer func() {
+		processExists = exists
+	}()
+
+	processExists = func(pid int) bool { return pid == 12345 }
+
+	if _, err := AcquireDeploymentLock("ns", "dp"); err == nil || !strings.Contains(err.Error(), "pid 12345") {
+		t.Fatalf("expected already active error, got %v", err)
+	}
+
+	processExists = func(pid int) bool { return false }
+	release, err = AcquireDeploymentLock("ns", "dp")
+	if err != nil {
+		t.Fatalf("stale lock wasn't reclaimed: %s", err)
+	}
+
+	if err := ioutil.WriteFile(lock, []byte("12345"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	release()
+	if !model.FileExists(lock) {
+		t.Fatal("the lock of another process was removed")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	namespaceEnvVar = "OKTETO_NAMESPACE"
+
+	defaultNamespace = "default"
+)
+
+// GetNamespace returns the active namespace: OKTETO_NAMESPACE, the namespace of the kube context or "default"
+func GetNamespace() string {
+	ns, err := GetNamespaceE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return ns
+}
+
+// GetNamespaceE returns the active namespace: OKTETO_NAMESPACE, the namespace of the kube context returned by GetKubeContextE or "default".
+// It returns an error if the namespace isn't a valid kubernetes namespace name
+func GetNamespaceE() (string, error) {
+	if v := os.Getenv(namespaceEnvVar); v != "" {
+		if err := validateNamespace(v, namespaceEnvVar); err != nil {
+			return "", err
+		}
+
+		log.Debugf("using namespace '%s' from %s", v, namespaceEnvVar)
+		return v, nil
+	}
+
+	cfg, err := LoadKubeConfig(context.Background())
+	if err != nil {
+		if !errors.Is(err, ErrKubeConfigNotFound) {
+			return "", err
+		}
+
+		cfg = clientcmdapi.NewConfig()
+	}
+
+	kubeCtx, err := kubeContext(cfg, os.Getenv(kubeContextEnvVar))
+	if err != nil {
+		return "", err
+	}
+
+	return namespaceOfContext(cfg, kubeCtx)
+}
+
+func namespaceOfContext(cfg *clientcmdapi.Config, kubeCtx string) (string, error) {
+	if c, ok := cfg.Contexts[kubeCtx]; ok && c.Namespace != "" {
+		if err := validateNamespace(c.Namespace, fmt.Sprintf("the kube context '%s'", kubeCtx)); err != nil {
+			return "", err
+		}
+
+		log.Debugf("using namespace '%s' from the kube context '%s'", c.Namespace, kubeCtx)
+		return c.Namespace, nil
+	}
+
+	log.Debugf("using the '%s' namespace", defaultNamespace)
+	return defaultNamespace, nil
+}
+
+func validateNamespace(namespace, source string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return newError(ErrInvalidName, nil, "'%s' defined in %s is not a valid namespace name: %s", namespace, source, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
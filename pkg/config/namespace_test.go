@@ -0,0 +1,116 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestGetNamespaceE(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["okteto"] = &clientcmdapi.Cluster{Server: "https://okteto.example.com"}
+	cfg.Contexts["okteto"] = &clientcmdapi.Context{Cluster: "okteto", Namespace: "cindy"}
+	cfg.Contexts["eks"] = &clientcmdapi.Context{Cluster: "okteto"}
+	cfg.Contexts["invalid"] = &clientcmdapi.Context{Cluster: "okteto", Namespace: "Not_Valid"}
+	cfg.CurrentContext = "okteto"
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name      string
+		env       map[string]string
+		expected  string
+		expectErr error
+	}{
+		{
+			name:     "env",
+			env:      map[string]string{"KUBECONFIG": path, "OKTETO_NAMESPACE": "staging"},
+			expected: "staging",
+		},
+		{
+			name:     "current-context",
+			env:      map[string]string{"KUBECONFIG": path},
+			expected: "cindy",
+		},
+		{
+			name:     "context-without-namespace",
+			env:      map[string]string{"KUBECONFIG": path, "OKTETO_CONTEXT": "eks"},
+			expected: "default",
+		},
+		{
+			name:     "no-kubeconfig",
+			env:      map[string]string{"KUBECONFIG": filepath.Join(dir, "missing")},
+			expected: "default",
+		},
+		{
+			name:      "invalid-env",
+			env:       map[string]string{"KUBECONFIG": path, "OKTETO_NAMESPACE": "Staging"},
+			expectErr: ErrInvalidName,
+		},
+		{
+			name:      "invalid-context-namespace",
+			env:       map[string]string{"KUBECONFIG": path, "OKTETO_CONTEXT": "invalid"},
+			expectErr: ErrInvalidName,
+		},
+		{
+			name:      "unknown-context",
+			env:       map[string]string{"KUBECONFIG": path, "OKTETO_CONTEXT": "gke"},
+			expectErr: ErrKubeContextNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer func() {
+				for k := range tt.env {
+					os.Unsetenv(k)
+				}
+			}()
+
+			got, err := GetNamespaceE()
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected '%s', got '%v'", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
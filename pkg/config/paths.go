@@ -0,0 +1,341 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths represents the locations and settings used by okteto.
+// Tests can build their own instance instead of modifying the environment
+type Paths struct {
+	// Home is the okteto folder
+	Home string
+
+	// KubeConfig are the kubeconfig files, in order of precedence
+	KubeConfig []string
+
+	// Timeout is the per-action timeout
+	Timeout time.Duration
+
+	// fromEnv is true for the instances of DefaultPaths, that resolve KubeConfig and Timeout on first use
+	fromEnv        bool
+	kubeConfigOnce sync.Once
+	timeoutOnce    sync.Once
+}
+
+// DefaultPaths returns the paths resolved from the environment.
+// The kubeconfig files and the timeout are resolved the first time they are used
+func DefaultPaths() (*Paths, error) {
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Paths{Home: home, fromEnv: true}, nil
+}
+
+// KubeConfigFiles returns the kubeconfig files, in order of precedence
+func (p *Paths) KubeConfigFiles() []string {
+	if p.fromEnv {
+		p.kubeConfigOnce.Do(func() {
+			p.KubeConfig = GetKubeConfigFiles()
+		})
+	}
+
+	return p.KubeConfig
+}
+
+// ActionTimeout returns the per-action timeout
+func (p *Paths) ActionTimeout() time.Duration {
+	if p.fromEnv {
+		p.timeoutOnce.Do(func() {
+			p.Timeout = GetTimeout()
+		})
+	}
+
+	return p.Timeout
+}
+
+// KubeConfigFile returns the kubeconfig file okteto writes to
+func (p *Paths) KubeConfigFile() string {
+	files := p.KubeConfigFiles()
+	if len(files) == 0 {
+		return ""
+	}
+
+	return files[0]
+}
+
+// NamespaceHome returns the path of the folder of a namespace, creating it if needed
+func (p *Paths) NamespaceHome(namespace string) (string, error) {
+	ns, err := encodePathComponent(namespace)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	d := filepath.Join(p.Home, ns)
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
+
+// ListNamespaceHomes returns the names of the namespaces with a folder in the okteto home
+func (p *Paths) ListNamespaceHomes() ([]string, error) {
+	folders, err := p.namespaceFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{}
+	for _, f := range folders {
+		namespaces = append(namespaces, decodePathComponent(f))
+	}
+
+	return namespaces, nil
+}
+
+// namespaceFolders returns the folders of the okteto home that belong to a namespace
+func (p *Paths) namespaceFolders() ([]string, error) {
+	entries, err := ioutil.ReadDir(p.Home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p.Home, err)
+	}
+
+	folders := []string{}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		folders = append(folders, e.Name())
+	}
+
+	return folders, nil
+}
+
+// StaleNamespaceHomes returns the names of the namespaces whose folder wasn't modified in the last maxAge.
+// A folder is as recent as the most recent file it contains. The folders of the okteto contexts are never returned
+func (p *Paths) StaleNamespaceHomes(maxAge time.Duration) ([]string, error) {
+	folders, err := p.staleNamespaceFolders(maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := []string{}
+	for _, f := range folders {
+		stale = append(stale, decodePathComponent(f))
+	}
+
+	return stale, nil
+}
+
+func (p *Paths) staleNamespaceFolders(maxAge time.Duration) ([]string, error) {
+	folders, err := p.namespaceFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := time.Now().Add(-maxAge)
+	stale := []string{}
+	for _, f := range folders {
+		d := filepath.Join(p.Home, f)
+		modTime, err := lastModTime(d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect %s: %w", d, err)
+		}
+
+		if modTime.Before(limit) {
+			stale = append(stale, f)
+		}
+	}
+
+	return stale, nil
+}
+
+// PruneOldNamespaceHomes removes the folders returned by StaleNamespaceHomes and returns their namespaces
+func (p *Paths) PruneOldNamespaceHomes(maxAge time.Duration) ([]string, error) {
+	if IsReadOnly() {
+		return nil, newError(ErrReadOnly, nil, "namespace folders can't be removed, okteto is running in read-only mode")
+	}
+
+	stale, err := p.staleNamespaceFolders(maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, f := range stale {
+		d := filepath.Join(p.Home, f)
+		if err := os.RemoveAll(d); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", d, err)
+		}
+		removed = append(removed, decodePathComponent(f))
+	}
+
+	return removed, nil
+}
+
+// lastModTime returns the most recent modification time of d and the files it contains
+func lastModTime(d string) (time.Time, error) {
+	var last time.Time
+	err := filepath.Walk(d, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return last, err
+}
+
+// DeploymentHome returns the path of the folder of a deployment, creating it if needed
+func (p *Paths) DeploymentHome(namespace, name string) (string, error) {
+	d, err := p.deploymentPath(namespace, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ensureDir(d); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
+
+// RemoveDeploymentHome removes the folder of a deployment, if it exists
+func (p *Paths) RemoveDeploymentHome(namespace, name string) error {
+	d, err := p.deploymentPath(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(p.Home, d)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("%s is not inside %s", d, p.Home)
+	}
+
+	if err := os.RemoveAll(d); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", d, err)
+	}
+
+	return nil
+}
+
+// ListDeploymentFiles returns the files stored in the folder of a deployment, relative to it.
+// It returns an empty list if the folder doesn't exist
+func (p *Paths) ListDeploymentFiles(namespace, name string) ([]string, error) {
+	d, err := p.deploymentPath(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	err = filepath.Walk(d, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == d {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", d, err)
+	}
+
+	return files, nil
+}
+
+func (p *Paths) deploymentPath(namespace, name string) (string, error) {
+	ns, err := encodePathComponent(namespace)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	n, err := encodePathComponent(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid name: %w", err)
+	}
+
+	return filepath.Join(p.Home, ns, n), nil
+}
+
+// encodePathComponent returns a folder name that is safe on every OS for a namespace or deployment name.
+// Characters that are not allowed in a folder name are percent-encoded so decodePathComponent can revert it
+func encodePathComponent(value string) (string, error) {
+	if err := validatePathComponent(value); err != nil {
+		return "", err
+	}
+
+	return escapeFolderName(value), nil
+}
+
+func escapeFolderName(value string) string {
+	var sb strings.Builder
+	for i, r := range value {
+		switch {
+		case r < 0x20, r == 0x7f, strings.ContainsRune(`%<>:"|?*/\`, r):
+			fmt.Fprintf(&sb, "%%%02X", r)
+		case i == 0 && r == '.':
+			sb.WriteString("%2E")
+		case i == len(value)-1 && (r == '.' || r == ' '):
+			fmt.Fprintf(&sb, "%%%02X", r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	escaped := sb.String()
+	device := strings.ToUpper(strings.SplitN(escaped, ".", 2)[0])
+	if windowsDeviceNames[device] {
+		escaped = fmt.Sprintf("%%%02X", escaped[0]) + escaped[1:]
+	}
+
+	return escaped
+}
+
+// decodePathComponent reverts encodePathComponent. Folders that weren't encoded are returned as they are
+func decodePathComponent(value string) string {
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return value
+	}
+
+	return decoded
+}
@@ -0,0 +1,328 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := &Paths{
+		Home:       dir,
+		KubeConfig: []string{filepath.Join(dir, "config"), filepath.Join(dir, "other")},
+	}
+
+	if got := p.KubeConfigFile(); got != filepath.Join(dir, "config") {
+		t.Errorf("got %s, expected %s", got, filepath.Join(dir, "config"))
+	}
+
+	d, err := p.DeploymentHome("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Join(dir, "ns", "dp"); d != expected {
+		t.Errorf("got %s, expected %s", d, expected)
+	}
+
+	if _, err := p.NamespaceHome("ns-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	namespaces, err := p.ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"ns", "ns-2"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("got %v, expected %v", namespaces, expected)
+	}
+
+	if err := p.RemoveDeploymentHome("ns", "dp"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(d); !os.IsNotExist(err) {
+		t.Errorf("%s wasn't removed", d)
+	}
+}
+
+func TestDefaultPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	p, err := DefaultPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Home != dir {
+		t.Errorf("got %s, expected %s", p.Home, dir)
+	}
+
+	if p.KubeConfig != nil || p.Timeout != 0 {
+		t.Errorf("the kubeconfig files and the timeout were resolved before being used: %v %s", p.KubeConfig, p.Timeout)
+	}
+
+	if got := p.ActionTimeout(); got != GetTimeout() {
+		t.Errorf("got %s, expected %s", got, GetTimeout())
+	}
+
+	if got := p.KubeConfigFiles(); !reflect.DeepEqual(got, GetKubeConfigFiles()) {
+		t.Errorf("got %v, expected %v", got, GetKubeConfigFiles())
+	}
+}
+
+func TestEncodePathComponent(t *testing.T) {
+	var tests = []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "plain", value: "my-namespace", expected: "my-namespace"},
+		{name: "unicode", value: "espacio-ñandú-名前", expected: "espacio-ñandú-名前"},
+		{name: "colon", value: "team:dev", expected: "team%3Adev"},
+		{name: "percent", value: "100%", expected: "100%25"},
+		{name: "reserved-chars", value: `a<b>c"d|e?f*g`, expected: "a%3Cb%3Ec%22d%7Ce%3Ff%2Ag"},
+		{name: "control-chars", value: "a\tb", expected: "a%09b"},
+		{name: "hidden", value: ".hidden", expected: "%2Ehidden"},
+		{name: "trailing-dot", value: "ns.", expected: "ns%2E"},
+		{name: "trailing-space", value: "ns ", expected: "ns%20"},
+		{name: "device", value: "con", expected: "%63on"},
+		{name: "device-upper", value: "LPT1", expected: "%4CPT1"},
+		{name: "device-extension", value: "nul.txt", expected: "%6Eul.txt"},
+		{name: "not-a-device", value: "console", expected: "console"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "dot", value: ".", wantErr: true},
+		{name: "dot-dot", value: "..", wantErr: true},
+		{name: "absolute", value: "/etc", wantErr: true},
+		{name: "traversal", value: `..\etc`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodePathComponent(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for '%s', got '%s'", tt.value, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+
+			if decoded := decodePathComponent(got); decoded != tt.value {
+				t.Errorf("decoded '%s', expected '%s'", decoded, tt.value)
+			}
+		})
+	}
+}
+
+func TestListNamespaceHomesDecodes(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := &Paths{Home: dir}
+	for _, ns := range []string{"con", "team:dev"} {
+		if _, err := p.NamespaceHome(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	namespaces, err := p.ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"con", "team:dev"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("got %v, expected %v", namespaces, expected)
+	}
+}
+
+func TestListDeploymentFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := &Paths{Home: dir}
+	files, err := p.ListDeploymentFiles("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Errorf("expected no files for a missing folder, got %v", files)
+	}
+
+	d, err := p.DeploymentHome("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{"syncthing.pid", filepath.Join("index-v0.14.0.db", "LOCK")} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(d, f)), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(d, f), []byte("data"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(d, "empty"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = p.ListDeploymentFiles("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{filepath.Join("index-v0.14.0.db", "LOCK"), "syncthing.pid"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("got %v, expected %v", files, expected)
+	}
+
+	if _, err := p.ListDeploymentFiles("ns", ".."); err == nil {
+		t.Error("expected error for an invalid name")
+	}
+}
+
+func TestPruneOldNamespaceHomes(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := &Paths{Home: dir}
+	old := time.Now().Add(-30 * 24 * time.Hour)
+
+	for _, ns := range []string{"old", "recent", "old-with-recent-file"} {
+		d, err := p.DeploymentHome(ns, "dp")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := filepath.Join(d, "okteto.state")
+		if err := ioutil.WriteFile(f, []byte("ready"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if ns == "recent" {
+			continue
+		}
+
+		if ns == "old" {
+			if err := os.Chtimes(f, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, folder := range []string{d, filepath.Join(dir, ns)} {
+			if err := os.Chtimes(folder, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	ctx, err := p.ContextHome("cloud.okteto.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := filepath.Join(dir, settingsFileName)
+	if err := ioutil.WriteFile(settings, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{ctx, internalFolder(dir, contextFolderName), filepath.Join(dir, internalFolderName), settings} {
+		if err := os.Chtimes(f, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale, err := p.StaleNamespaceHomes(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"old"}; !reflect.DeepEqual(stale, expected) {
+		t.Fatalf("got %v, expected %v", stale, expected)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "old")); err != nil {
+		t.Fatalf("StaleNamespaceHomes removed a folder: %s", err)
+	}
+
+	removed, err := p.PruneOldNamespaceHomes(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(removed, stale) {
+		t.Errorf("got %v, expected %v", removed, stale)
+	}
+
+	namespaces, err := p.ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"old-with-recent-file", "recent"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("got %v, expected %v", namespaces, expected)
+	}
+
+	for _, f := range []string{ctx, settings} {
+		if _, err := os.Stat(f); err != nil {
+			t.Errorf("%s was removed", f)
+		}
+	}
+}
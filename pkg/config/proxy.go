@@ -0,0 +1,129 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig is the proxy configuration of the outbound http connections of okteto
+type ProxyConfig struct {
+	HTTPProxy  *url.URL
+	HTTPSProxy *url.URL
+	NoProxy    []string
+
+	proxyFunc func(*url.URL) (*url.URL, error)
+}
+
+// GetProxyConfig returns the proxy configuration defined by HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+// The OKTETO_ prefixed variables take precedence, so the proxy of okteto can be configured without affecting other tools
+func GetProxyConfig() (*ProxyConfig, error) {
+	return getProxyConfig(os.Getenv)
+}
+
+func getProxyConfig(getenv func(string) string) (*ProxyConfig, error) {
+	c := &httpproxy.Config{
+		HTTPProxy:  lookupProxyEnv(getenv, "HTTP_PROXY"),
+		HTTPSProxy: lookupProxyEnv(getenv, "HTTPS_PROXY"),
+		NoProxy:    lookupProxyEnv(getenv, "NO_PROXY"),
+	}
+
+	httpProxy, err := parseProxy("HTTP_PROXY", c.HTTPProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	httpsProxy, err := parseProxy("HTTPS_PROXY", c.HTTPSProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ProxyConfig{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		proxyFunc:  c.ProxyFunc(),
+	}
+
+	for _, v := range strings.Split(c.NoProxy, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			p.NoProxy = append(p.NoProxy, v)
+		}
+	}
+
+	return p, nil
+}
+
+// lookupProxyEnv returns the value of OKTETO_<name>, <name> or its lowercase version, in that order
+func lookupProxyEnv(getenv func(string) string, name string) string {
+	for _, k := range []string{"OKTETO_" + name, name, strings.ToLower(name)} {
+		if v := getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// parseProxy parses a proxy address, an address without scheme is an http proxy
+func parseProxy(name, value string) (*url.URL, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	addr := value
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%s is not a valid proxy address: %s", name, value)
+	}
+
+	return u, nil
+}
+
+// Proxy returns the proxy to use for req, or nil if req must not use a proxy.
+// Hosts in NO_PROXY are matched by domain suffix, IP address or CIDR, and loopback addresses never use a proxy
+func (p *ProxyConfig) Proxy(req *http.Request) (*url.URL, error) {
+	return p.proxyFunc(req.URL)
+}
+
+// NewHTTPTransport returns a transport with the settings of the default transport that uses the proxy configuration of GetProxyConfig.
+// Every outbound http client of okteto must be built with it, so the proxy configuration is applied consistently
+func NewHTTPTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	p, err := GetProxyConfig()
+	if err != nil {
+		// fail the requests instead of silently skipping the proxy
+		t.Proxy = func(*http.Request) (*url.URL, error) {
+			return nil, err
+		}
+		return t
+	}
+
+	t.Proxy = p.Proxy
+	return t
+}
+
+// NewHTTPClient returns an http client built with NewHTTPTransport that identifies itself with GetUserAgent
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: WithUserAgent(NewHTTPTransport())}
+}
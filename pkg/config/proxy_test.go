@@ -0,0 +1,178 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_getProxyConfig(t *testing.T) {
+	var tests = []struct {
+		name       string
+		env        map[string]string
+		httpProxy  string
+		httpsProxy string
+		noProxy    []string
+		expectErr  bool
+	}{
+		{
+			name: "empty",
+			env:  map[string]string{},
+		},
+		{
+			name: "standard",
+			env: map[string]string{
+				"HTTP_PROXY":  "http://proxy.corp:3128",
+				"HTTPS_PROXY": "http://secure.corp:3128",
+				"NO_PROXY":    "localhost, .internal,10.0.0.0/8",
+			},
+			httpProxy:  "http://proxy.corp:3128",
+			httpsProxy: "http://secure.corp:3128",
+			noProxy:    []string{"localhost", ".internal", "10.0.0.0/8"},
+		},
+		{
+			name: "lowercase",
+			env: map[string]string{
+				"https_proxy": "http://secure.corp:3128",
+				"no_proxy":    "example.com",
+			},
+			httpsProxy: "http://secure.corp:3128",
+			noProxy:    []string{"example.com"},
+		},
+		{
+			name: "okteto-prefixed",
+			env: map[string]string{
+				"HTTPS_PROXY":        "http://secure.corp:3128",
+				"OKTETO_HTTPS_PROXY": "http://okteto.corp:3128",
+				"NO_PROXY":           "example.com",
+				"OKTETO_NO_PROXY":    "okteto.dev",
+			},
+			httpsProxy: "http://okteto.corp:3128",
+			noProxy:    []string{"okteto.dev"},
+		},
+		{
+			name: "no-scheme",
+			env: map[string]string{
+				"HTTPS_PROXY": "secure.corp:3128",
+			},
+			httpsProxy: "http://secure.corp:3128",
+		},
+		{
+			name: "invalid",
+			env: map[string]string{
+				"HTTPS_PROXY": "http://[::1",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := getProxyConfig(func(k string) string { return tt.env[k] })
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := urlString(p.HTTPProxy); got != tt.httpProxy {
+				t.Errorf("got http proxy '%s', expected '%s'", got, tt.httpProxy)
+			}
+
+			if got := urlString(p.HTTPSProxy); got != tt.httpsProxy {
+				t.Errorf("got https proxy '%s', expected '%s'", got, tt.httpsProxy)
+			}
+
+			if !reflect.DeepEqual(p.NoProxy, tt.noProxy) {
+				t.Errorf("got no proxy %v, expected %v", p.NoProxy, tt.noProxy)
+			}
+		})
+	}
+}
+
+func TestProxyConfigProxy(t *testing.T) {
+	env := map[string]string{
+		"HTTP_PROXY":  "http://proxy.corp:3128",
+		"HTTPS_PROXY": "http://secure.corp:3128",
+		"NO_PROXY":    ".internal,10.0.0.0/8,registry.example.com",
+	}
+
+	p, err := getProxyConfig(func(k string) string { return env[k] })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://cloud.okteto.com/graphql", expected: "http://secure.corp:3128"},
+		{url: "http://example.com", expected: "http://proxy.corp:3128"},
+		{url: "https://api.internal/graphql", expected: ""},
+		{url: "https://registry.example.com/v2", expected: ""},
+		{url: "https://sub.registry.example.com/v2", expected: ""},
+		{url: "https://10.1.2.3:8443", expected: ""},
+		{url: "https://11.1.2.3:8443", expected: "http://secure.corp:3128"},
+		{url: "http://localhost:8384", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			u, err := p.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := urlString(u); got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewHTTPTransportInvalidProxy(t *testing.T) {
+	os.Setenv("OKTETO_HTTPS_PROXY", "http://[::1")
+	defer os.Unsetenv("OKTETO_HTTPS_PROXY")
+
+	req, err := http.NewRequest("GET", "https://cloud.okteto.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewHTTPTransport().Proxy(req); err == nil {
+		t.Error("expected the transport to fail with an invalid proxy")
+	}
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	return u.String()
+}
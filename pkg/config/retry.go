@@ -0,0 +1,69 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	okErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	retryInitialInterval = 500 * time.Millisecond
+	retryMaxInterval     = 10 * time.Second
+)
+
+// RetryWithTimeout calls fn until it succeeds, returns an error that can't be retried, or the timeout of action elapses.
+// fn receives a context that expires with the timeout, so a single attempt can't outlive it.
+// The wait between attempts grows exponentially. It stops as soon as ctx is cancelled
+func RetryWithTimeout(ctx context.Context, action string, fn func(context.Context) error) error {
+	return retryWithTimeout(ctx, action, GetTimeoutFor(action), retryInitialInterval, fn)
+}
+
+func retryWithTimeout(ctx context.Context, action string, timeout, interval time.Duration, fn func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if !okErrors.IsRetryable(err) {
+			return err
+		}
+
+		log.Debugf("%s failed on attempt %d, retrying in %s: %s", action, attempt, interval, err)
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("%s didn't succeed after %d attempts in %s: %w", action, attempt, timeout, err)
+			}
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		interval *= 2
+		if interval > retryMaxInterval {
+			interval = retryMaxInterval
+		}
+	}
+}
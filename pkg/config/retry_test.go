@@ -0,0 +1,124 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_retryWithTimeout(t *testing.T) {
+	transient := fmt.Errorf("dial tcp 10.0.0.1:443: connect: connection refused")
+	unauthorized := fmt.Errorf("Unauthorized")
+
+	var tests = []struct {
+		name        string
+		errs        []error
+		timeout     time.Duration
+		expectErr   error
+		expectCalls int
+		skipCalls   bool
+	}{
+		{
+			name:        "success",
+			errs:        []error{nil},
+			timeout:     time.Second,
+			expectCalls: 1,
+		},
+		{
+			name:        "success-after-retries",
+			errs:        []error{transient, transient, nil},
+			timeout:     time.Second,
+			expectCalls: 3,
+		},
+		{
+			name:        "not-retryable",
+			errs:        []error{transient, unauthorized, nil},
+			timeout:     time.Second,
+			expectErr:   unauthorized,
+			expectCalls: 2,
+		},
+		{
+			name:      "timeout",
+			errs:      []error{transient},
+			timeout:   50 * time.Millisecond,
+			expectErr: transient,
+			skipCalls: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retryWithTimeout(context.Background(), "test", tt.timeout, time.Millisecond, func(context.Context) error {
+				e := tt.errs[len(tt.errs)-1]
+				if calls < len(tt.errs) {
+					e = tt.errs[calls]
+				}
+				calls++
+				return e
+			})
+
+			if tt.expectErr == nil && err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if tt.expectErr != nil && !errors.Is(err, tt.expectErr) {
+				t.Fatalf("got %v, expected %v", err, tt.expectErr)
+			}
+
+			if !tt.skipCalls && calls != tt.expectCalls {
+				t.Errorf("got %d calls, expected %d", calls, tt.expectCalls)
+			}
+		})
+	}
+}
+
+func Test_retryWithTimeoutCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retryWithTimeout(ctx, "test", time.Minute, time.Millisecond, func(context.Context) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return fmt.Errorf("i/o timeout")
+	})
+
+	if err != context.Canceled {
+		t.Errorf("got %v, expected %v", err, context.Canceled)
+	}
+
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2", calls)
+	}
+}
+
+func Test_retryWithTimeoutDeadline(t *testing.T) {
+	err := retryWithTimeout(context.Background(), "test", 50*time.Millisecond, time.Millisecond, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("the attempt didn't get the deadline of the timeout")
+		}
+
+		<-ctx.Done()
+		return fmt.Errorf("i/o timeout")
+	})
+
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ServerInfo describes the API server of a kube context, to diagnose TLS errors
+type ServerInfo struct {
+	Context               string `json:"context"`
+	Server                string `json:"server"`
+	TLSServerName         string `json:"tlsServerName,omitempty"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify,omitempty"`
+	CAFile                string `json:"caFile,omitempty"`
+	CASubject             string `json:"caSubject,omitempty"`
+	Token                 string `json:"token,omitempty"`
+}
+
+// GetServerNameFromKubeConfig returns the API server and the CA of a kube context.
+// If name is empty, it uses the context returned by GetKubeContextE. Credentials are always redacted
+func GetServerNameFromKubeConfig(name string) (*ServerInfo, error) {
+	cfg, err := LoadKubeConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name, err = kubeContext(cfg, os.Getenv(kubeContextEnvVar))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return getServerInfo(cfg, name)
+}
+
+func getServerInfo(cfg *clientcmdapi.Config, name string) (*ServerInfo, error) {
+	if name == "" {
+		return nil, newError(ErrKubeContextNotFound, nil, "your kubeconfig doesn't have a current context")
+	}
+
+	kubeCtx, ok := cfg.Contexts[name]
+	if !ok {
+		return nil, newError(ErrKubeContextNotFound, nil, "context '%s' not found in your kubeconfig", name)
+	}
+
+	cluster, ok := cfg.Clusters[kubeCtx.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster '%s' of context '%s' not found in your kubeconfig", kubeCtx.Cluster, name)
+	}
+
+	info := &ServerInfo{
+		Context:               name,
+		Server:                redactURL(cluster.Server),
+		TLSServerName:         cluster.TLSServerName,
+		InsecureSkipTLSVerify: cluster.InsecureSkipTLSVerify,
+	}
+
+	ca := cluster.CertificateAuthorityData
+	if len(ca) == 0 && cluster.CertificateAuthority != "" {
+		info.CAFile = cluster.CertificateAuthority
+		b, err := ioutil.ReadFile(cluster.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA of cluster '%s': %w", kubeCtx.Cluster, err)
+		}
+		ca = b
+	}
+
+	if len(ca) > 0 {
+		subject, err := getCASubject(ca)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CA of cluster '%s': %w", kubeCtx.Cluster, err)
+		}
+		info.CASubject = subject
+	}
+
+	if user, ok := cfg.AuthInfos[kubeCtx.AuthInfo]; ok && (user.Token != "" || user.TokenFile != "") {
+		info.Token = redactedValue
+	}
+
+	return info, nil
+}
+
+// getCASubject returns the subjects of the PEM encoded certificates of a CA bundle
+func getCASubject(data []byte) (string, error) {
+	subjects := []string{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", err
+		}
+
+		subjects = append(subjects, cert.Subject.String())
+	}
+
+	if len(subjects) == 0 {
+		return "", fmt.Errorf("no PEM encoded certificates found")
+	}
+
+	return strings.Join(subjects, "; "), nil
+}
+
+// redactURL hides the credentials embedded in a server URL
+func redactURL(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.User == nil {
+		return server
+	}
+
+	u.User = url.User(redactedValue)
+	return u.String()
+}
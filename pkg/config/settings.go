@@ -0,0 +1,155 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	settingsFileName = "settings.json"
+
+	namespaceSetting  = "namespace"
+	autoUpdateSetting = "autoUpdate"
+	analyticsSetting  = "analytics"
+)
+
+// Settings are the user preferences persisted across runs.
+// Settings unknown to this version are kept as they are, so older binaries don't drop the settings of newer ones
+type Settings struct {
+	path   string
+	values map[string]json.RawMessage
+}
+
+// LoadSettings returns the settings stored in the okteto folder.
+// If the settings file is missing, every setting has its default value
+func LoadSettings() (*Settings, error) {
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return nil, err
+	}
+
+	return loadSettings(filepath.Join(home, settingsFileName))
+}
+
+func loadSettings(path string) (*Settings, error) {
+	s := &Settings{path: path, values: map[string]json.RawMessage{}}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		return s, nil
+	}
+
+	if err := json.Unmarshal(b, &s.values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if s.values == nil {
+		s.values = map[string]json.RawMessage{}
+	}
+
+	return s, nil
+}
+
+// Save writes the settings to the okteto folder
+func (s *Settings) Save() error {
+	if IsReadOnly() {
+		return newError(ErrReadOnly, nil, "%s can't be saved, okteto is running in read-only mode", s.path)
+	}
+
+	b, err := json.MarshalIndent(s.values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the settings: %w", err)
+	}
+
+	return WriteFileAtomic(s.path, b, 0600)
+}
+
+// Namespace returns the preferred namespace, or an empty string if it's not set
+func (s *Settings) Namespace() string {
+	var ns string
+	s.get(namespaceSetting, &ns)
+	return ns
+}
+
+// SetNamespace sets the preferred namespace. An empty namespace removes the setting
+func (s *Settings) SetNamespace(ns string) {
+	if ns == "" {
+		delete(s.values, namespaceSetting)
+		return
+	}
+
+	s.set(namespaceSetting, ns)
+}
+
+// AutoUpdate returns if okteto must update itself when a new version is available. It's disabled by default
+func (s *Settings) AutoUpdate() bool {
+	var enabled bool
+	s.get(autoUpdateSetting, &enabled)
+	return enabled
+}
+
+// SetAutoUpdate enables or disables the automatic updates
+func (s *Settings) SetAutoUpdate(enabled bool) {
+	s.set(autoUpdateSetting, enabled)
+}
+
+// Analytics returns if the analytics are enabled. They are enabled by default
+func (s *Settings) Analytics() bool {
+	enabled := true
+	s.get(analyticsSetting, &enabled)
+	return enabled
+}
+
+// SetAnalytics enables or disables the analytics
+func (s *Settings) SetAnalytics(enabled bool) {
+	s.set(analyticsSetting, enabled)
+}
+
+// get decodes the setting key into v. v keeps its value if the setting is missing or invalid
+func (s *Settings) get(key string, v interface{}) {
+	raw, ok := s.values[key]
+	if !ok {
+		return
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		log.Infof("ignoring invalid setting '%s' in %s: %s", key, s.path, err)
+	}
+}
+
+func (s *Settings) set(key string, v interface{}) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		log.Infof("failed to serialize setting '%s': %s", key, err)
+		return
+	}
+
+	s.values[key] = raw
+}
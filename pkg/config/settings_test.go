@@ -0,0 +1,145 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSettingsDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	s, err := LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Namespace() != "" {
+		t.Errorf("got namespace %s, expected none", s.Namespace())
+	}
+
+	if s.AutoUpdate() {
+		t.Error("auto update is enabled by default")
+	}
+
+	s.SetNamespace("cindy")
+	s.SetAutoUpdate(true)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Namespace() != "cindy" {
+		t.Errorf("got namespace %s, expected cindy", s.Namespace())
+	}
+
+	if !s.AutoUpdate() {
+		t.Error("auto update wasn't saved")
+	}
+}
+
+func TestSettingsKeepUnknownFields(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, settingsFileName)
+	if err := ioutil.WriteFile(p, []byte(`{"namespace": "cindy", "telemetry": {"enabled": false}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := loadSettings(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetNamespace("")
+	s.SetAutoUpdate(true)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := got["namespace"]; ok {
+		t.Error("namespace wasn't removed")
+	}
+
+	if got["autoUpdate"] != true {
+		t.Errorf("got autoUpdate %v, expected true", got["autoUpdate"])
+	}
+
+	telemetry, ok := got["telemetry"].(map[string]interface{})
+	if !ok || telemetry["enabled"] != false {
+		t.Errorf("unknown field wasn't preserved: %s", string(b))
+	}
+}
+
+func TestLoadSettingsInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, settingsFileName)
+	if err := ioutil.WriteFile(p, []byte(`{"namespace": 1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := loadSettings(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Namespace() != "" {
+		t.Errorf("got namespace %s for an invalid value", s.Namespace())
+	}
+
+	if err := ioutil.WriteFile(p, []byte(`not json`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadSettings(p); err == nil {
+		t.Error("expected an error for a malformed file")
+	}
+}
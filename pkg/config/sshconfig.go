@@ -0,0 +1,277 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	sshConfigBeginMarker = "# begin okteto managed entry: %s"
+	sshConfigEndMarker   = "# end okteto managed entry: %s"
+
+	// sshConfigLegacyComment is the comment of the entries written by previous versions of okteto
+	sshConfigLegacyComment = "# entry generated by okteto"
+)
+
+// ErrSSHHostNotFound is returned when the ssh config doesn't have an okteto entry for a host
+var ErrSSHHostNotFound = fmt.Errorf("ssh host not found")
+
+// SSHHost is the entry okteto manages in the ssh config of the user, so editors can attach to the development container
+type SSHHost struct {
+	Host     string
+	HostName string
+	Port     int
+	User     string
+	Options  []SSHOption
+}
+
+// SSHOption is an additional keyword of an SSHHost
+type SSHOption struct {
+	Keyword string
+	Value   string
+}
+
+// GetSSHConfigPath returns the path of the ssh config of the user
+func GetSSHConfigPath() (string, error) {
+	home, err := GetUserHomeDirE()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".ssh", "config"), nil
+}
+
+// GetOrCreateSSHConfig upserts the block of host in the ssh config of the user and returns the path of the file.
+// The block is delimited by markers, so the rest of the entries of the file aren't modified
+func GetOrCreateSSHConfig(host *SSHHost) (string, error) {
+	if host.Host == "" || strings.ContainsAny(host.Host, " \t") {
+		return "", fmt.Errorf("'%s' is not a valid ssh host", host.Host)
+	}
+
+	path, err := GetSSHConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	if err := updateSSHConfig(path, host.Host, renderSSHHost(host)); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// RemoveSSHConfig removes the block of host from the ssh config of the user, if it exists
+func RemoveSSHConfig(host string) error {
+	path, err := GetSSHConfigPath()
+	if err != nil {
+		return err
+	}
+
+	return updateSSHConfig(path, host, nil)
+}
+
+// GetSSHConfigPort returns the port of the block of host in the ssh config of the user
+func GetSSHConfigPort(host string) (int, error) {
+	path, err := GetSSHConfigPath()
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, ErrSSHHostNotFound
+		}
+
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return getSSHBlockPort(splitSSHConfig(string(b)), host)
+}
+
+func getSSHBlockPort(lines []string, host string) (int, error) {
+	begin := fmt.Sprintf(sshConfigBeginMarker, host)
+	end := fmt.Sprintf(sshConfigEndMarker, host)
+
+	inBlock := false
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		switch {
+		case l == begin:
+			inBlock = true
+		case l == end:
+			inBlock = false
+		case inBlock:
+			fields := strings.Fields(l)
+			if len(fields) == 2 && strings.EqualFold(fields[0], "Port") {
+				port, err := strconv.Atoi(fields[1])
+				if err != nil {
+					return 0, fmt.Errorf("invalid port: %s", fields[1])
+				}
+
+				return port, nil
+			}
+		}
+	}
+
+	return 0, ErrSSHHostNotFound
+}
+
+func renderSSHHost(host *SSHHost) []string {
+	lines := []string{
+		fmt.Sprintf("Host %s", host.Host),
+		fmt.Sprintf("  HostName %s", host.HostName),
+		fmt.Sprintf("  Port %d", host.Port),
+	}
+
+	if host.User != "" {
+		lines = append(lines, fmt.Sprintf("  User %s", host.User))
+	}
+
+	for _, o := range host.Options {
+		lines = append(lines, fmt.Sprintf("  %s %s", o.Keyword, o.Value))
+	}
+
+	return lines
+}
+
+// updateSSHConfig replaces the block of host in the ssh config at path with entry. A nil entry removes the block
+func updateSSHConfig(path, host string, entry []string) error {
+	// dotfiles managers usually link the ssh config, the link is preserved
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	var mode os.FileMode = 0600
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if entry == nil {
+			return nil
+		}
+	} else if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	lines, legacy := removeLegacySSHHost(splitSSHConfig(string(b)), host)
+	lines, found, err := replaceSSHBlock(lines, host, entry)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	if entry == nil && !found && !legacy {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+
+	return WriteFileAtomic(path, []byte(content), mode)
+}
+
+func splitSSHConfig(content string) []string {
+	content = strings.TrimRight(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if content == "" {
+		return []string{}
+	}
+
+	return strings.Split(content, "\n")
+}
+
+// replaceSSHBlock returns lines with the block of host replaced by entry, and if the block was found.
+// If the block isn't found, entry is appended
+func replaceSSHBlock(lines []string, host string, entry []string) ([]string, bool, error) {
+	begin := fmt.Sprintf(sshConfigBeginMarker, host)
+	end := fmt.Sprintf(sshConfigEndMarker, host)
+
+	var block []string
+	if entry != nil {
+		block = append(append([]string{begin}, entry...), end)
+	}
+
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) != begin {
+			continue
+		}
+
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == end {
+				start := i
+				if block == nil && start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+					// the blank line added before the block
+					start--
+				}
+
+				result := append(append(append([]string{}, lines[:start]...), block...), lines[j+1:]...)
+				return trimBlankLines(result), true, nil
+			}
+		}
+
+		return nil, false, fmt.Errorf("the okteto entry of '%s' doesn't have an end marker", host)
+	}
+
+	if block == nil {
+		return lines, false, nil
+	}
+
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		lines = append(lines, "")
+	}
+
+	return append(lines, block...), false, nil
+}
+
+// removeLegacySSHHost returns lines without the entry of host written by previous versions of okteto, and if it was found
+func removeLegacySSHHost(lines []string, host string) ([]string, bool) {
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i-1]) != sshConfigLegacyComment || strings.TrimSpace(lines[i]) != fmt.Sprintf("Host %s", host) {
+			continue
+		}
+
+		// the params of the entry are indented
+		j := i + 1
+		for j < len(lines) && strings.TrimSpace(lines[j]) != "" && strings.TrimLeft(lines[j], " \t") != lines[j] {
+			j++
+		}
+
+		result := append(append([]string{}, lines[:i-1]...), lines[j:]...)
+		return trimBlankLines(result), true
+	}
+
+	return lines, false
+}
+
+// trimBlankLines removes the blank lines left at the end of the file when a block is removed
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
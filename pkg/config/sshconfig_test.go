@@ -0,0 +1,176 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUpdateSSHConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the .ssh folder doesn't exist yet
+	path := filepath.Join(dir, ".ssh", "config")
+	host := &SSHHost{Host: "api.okteto", HostName: "localhost", Port: 22000, User: "root"}
+	if err := updateSSHConfig(path, host.Host, renderSSHHost(host)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# begin okteto managed entry: api.okteto
+Host api.okteto
+  HostName localhost
+  Port 22000
+  User root
+# end okteto managed entry: api.okteto
+`
+	assertFileContent(t, path, expected)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("got permissions %s, expected 0600", info.Mode().Perm())
+		}
+	}
+
+	user := "Host github.com\n  User git\n"
+	if err := ioutil.WriteFile(path, []byte(user+"\n"+expected), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	host.Port = 22001
+	if err := updateSSHConfig(path, host.Host, renderSSHHost(host)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFileContent(t, path, user+"\n"+strings.Replace(expected, "22000", "22001", 1))
+
+	if err := updateSSHConfig(path, host.Host, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFileContent(t, path, user)
+
+	if err := updateSSHConfig(path, host.Host, nil); err != nil {
+		t.Fatalf("removing a missing entry failed: %s", err)
+	}
+
+	assertFileContent(t, path, user)
+}
+
+func TestUpdateSSHConfigLegacyEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	user := "Host github.com\n  User git\n"
+	legacy := "# entry generated by okteto\nHost api.okteto\n  HostName localhost\n  Port 22000\n"
+	if err := ioutil.WriteFile(path, []byte(user+"\n"+legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	host := &SSHHost{Host: "api.okteto", HostName: "localhost", Port: 22001, Options: []SSHOption{{Keyword: "ForwardAgent", Value: "yes"}}}
+	if err := updateSSHConfig(path, host.Host, renderSSHHost(host)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# begin okteto managed entry: api.okteto
+Host api.okteto
+  HostName localhost
+  Port 22001
+  ForwardAgent yes
+# end okteto managed entry: api.okteto
+`
+	assertFileContent(t, path, user+"\n"+expected)
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := getSSHBlockPort(splitSSHConfig(string(b)), host.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if port != 22001 {
+		t.Errorf("got port %d, expected 22001", port)
+	}
+
+	if _, err := getSSHBlockPort(splitSSHConfig(string(b)), "github.com"); err != ErrSSHHostNotFound {
+		t.Errorf("got %v, expected ErrSSHHostNotFound", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(user+"\n"+legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := updateSSHConfig(path, host.Host, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFileContent(t, path, user)
+}
+
+func TestUpdateSSHConfigWithoutEndMarker(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	content := "# begin okteto managed entry: api.okteto\nHost api.okteto\nHost github.com\n  User git\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := updateSSHConfig(path, "api.okteto", nil); err == nil {
+		t.Fatal("expected an error when the end marker is missing")
+	}
+
+	assertFileContent(t, path, content)
+}
+
+func TestRemoveMissingSSHConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".ssh", "config")
+	if err := updateSSHConfig(path, "api.okteto", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("the .ssh folder was created to remove an entry")
+	}
+}
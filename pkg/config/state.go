@@ -0,0 +1,172 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	stateFileName = "okteto.state"
+
+	manifestHashFileName = "okteto.hash"
+
+	// stateDataFileName stores the typed state of ReadState and WriteState.
+	// It's separate from the state file, that other tools read as plain text
+	stateDataFileName = "okteto.state.json"
+
+	stateLockFileName = "okteto.state.lock"
+)
+
+// stateMutexes serializes the access to the state of a deployment within the process, indexed by the path of the lock file
+var stateMutexes sync.Map
+
+// GetStateFile returns the path of the state file of a deployment
+func GetStateFile(namespace, name string) string {
+	return filepath.Join(GetDeploymentHome(namespace, name), stateFileName)
+}
+
+// ReadStateFile returns the content of the state file of a deployment
+func ReadStateFile(namespace, name string) ([]byte, error) {
+	return ioutil.ReadFile(GetStateFile(namespace, name))
+}
+
+// WriteStateFile replaces the content of the state file of a deployment.
+// The content is written to a temporary file first, so readers never see a partial write
+func WriteStateFile(namespace, name string, data []byte) error {
+	return WriteFileAtomic(GetStateFile(namespace, name), data, 0644)
+}
+
+// ReadManifestHash returns the hash of the manifest used the last time the development container was activated.
+// It's stored next to the state file, so the format of the state file doesn't change for the tools that read it
+func ReadManifestHash(namespace, name string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(GetDeploymentHome(namespace, name), manifestHashFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// WriteManifestHash stores the hash of the manifest used to activate the development container
+func WriteManifestHash(namespace, name, hash string) error {
+	return WriteFileAtomic(filepath.Join(GetDeploymentHome(namespace, name), manifestHashFileName), []byte(hash), 0644)
+}
+
+// ReadState unmarshals the JSON state of a deployment into v.
+// If the deployment has no state, v is set to its zero value and no error is returned
+func ReadState(namespace, name string, v interface{}) error {
+	d, err := GetDeploymentHomeE(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	return withStateLock(d, func() error {
+		p := filepath.Join(d, stateDataFileName)
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+					rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+				}
+				return nil
+			}
+
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+
+		if err := json.Unmarshal(b, v); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+
+		return nil
+	})
+}
+
+// WriteState replaces the JSON state of a deployment with v.
+// Goroutines and processes accessing the same state are serialized, and the file is written atomically
+func WriteState(namespace, name string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to serialize the state of %s/%s: %w", namespace, name, err)
+	}
+
+	d, err := GetDeploymentHomeE(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	return withStateLock(d, func() error {
+		return WriteFileAtomic(filepath.Join(d, stateDataFileName), b, 0600)
+	})
+}
+
+// withStateLock runs fn holding the lock of the state of the deployment stored in dir.
+// The mutex covers the goroutines of this process, and the file lock the other processes
+func withStateLock(dir string, fn func() error) error {
+	p := filepath.Join(dir, stateLockFileName)
+	m, _ := stateMutexes.LoadOrStore(p, &sync.Mutex{})
+	mu := m.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", p, err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", p, err)
+	}
+	defer unlockFile(f)
+
+	return fn()
+}
+
+// WriteFileAtomic writes data to a temporary file in the same folder as path and renames it over path
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), fmt.Sprintf(".%s-", filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file for %s: %w", path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file for %s: %w", path, err)
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("failed to set permissions of temporary file for %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStateFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	expected := filepath.Join(dir, "ns", "dp", "okteto.state")
+	if got := GetStateFile("ns", "dp"); got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if _, err := ReadStateFile("ns", "dp"); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := WriteStateFile("ns", "dp", []byte(fmt.Sprintf("state-%d", i))); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := ReadStateFile("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) < len("state-0") {
+		t.Errorf("got a partial state: %s", string(got))
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "ns", "dp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Errorf("expected only the state file, got %d files", len(files))
+	}
+}
+
+func TestManifestHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	if _, err := ReadManifestHash("ns", "dp"); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+
+	if err := WriteStateFile("ns", "dp", []byte("ready")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteManifestHash("ns", "dp", "abcdef"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadManifestHash("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "abcdef" {
+		t.Errorf("got %s, expected abcdef", got)
+	}
+
+	state, err := ReadStateFile("ns", "dp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(state) != "ready" {
+		t.Errorf("the state file was modified: %s", string(state))
+	}
+}
+
+func TestState(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	type state struct {
+		Pid      int      `json:"pid"`
+		Services []string `json:"services"`
+	}
+
+	got := state{Pid: 1, Services: []string{"previous"}}
+	if err := ReadState("ns", "dp", &got); err != nil {
+		t.Fatalf("missing state returned an error: %s", err)
+	}
+
+	if got.Pid != 0 || got.Services != nil {
+		t.Errorf("missing state isn't the zero value: %+v", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := WriteState("ns", "dp", state{Pid: i, Services: []string{fmt.Sprintf("svc-%d", i)}}); err != nil {
+				t.Error(err)
+			}
+
+			var s state
+			if err := ReadState("ns", "dp", &s); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := ReadState("ns", "dp", &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Pid == 0 || len(got.Services) != 1 || got.Services[0] != fmt.Sprintf("svc-%d", got.Pid) {
+		t.Errorf("got an inconsistent state: %+v", got)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "ns", "dp", stateDataFileName), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReadState("ns", "dp", &got); err == nil {
+		t.Error("expected error when the state is not valid JSON")
+	}
+}
+
+func Test_lockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, stateLockFileName)
+	first, err := os.OpenFile(p, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	if err := lockFile(first); err != nil {
+		t.Fatal(err)
+	}
+
+	// a second handle behaves like another process
+	second, err := os.OpenFile(p, os.O_RDWR, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	locked := make(chan error, 1)
+	go func() {
+		locked <- lockFile(second)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the lock was acquired while it was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := unlockFile(first); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-locked:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the lock wasn't acquired after it was released")
+	}
+
+	if err := unlockFile(second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, ".token.json")
+	if err := ioutil.WriteFile(p, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(p, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "new" {
+		t.Errorf("got %s, expected new", string(got))
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("got permissions %o, expected 600", info.Mode().Perm())
+		}
+	}
+
+	target := filepath.Join(dir, "folder")
+	if err := os.MkdirAll(filepath.Join(target, "child"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(target, []byte("data"), 0600); err == nil {
+		t.Error("expected error when renaming over a non-empty folder")
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 {
+		t.Errorf("temporary files weren't cleaned up, got %d files", len(files))
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	tempFolderName = "tmp"
+
+	tempFolderPermissions os.FileMode = 0700
+)
+
+// GetTempDir returns the folder for scratch files inside the okteto home
+func GetTempDir() string {
+	d, err := GetTempDirE()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return d
+}
+
+// GetTempDirE returns the folder for scratch files inside the okteto home, or an error if it can't be created.
+// Only the user can access it, so it's safe to store credentials in it
+func GetTempDirE() (string, error) {
+	home, err := GetOktetoHomeE()
+	if err != nil {
+		return "", err
+	}
+
+	d := internalFolder(home, tempFolderName)
+	if IsReadOnly() {
+		return d, ensureDir(d)
+	}
+
+	if err := os.MkdirAll(d, tempFolderPermissions); err != nil {
+		return "", newError(ErrHomeNotWritable, err, "failed to create %s", d)
+	}
+
+	if err := os.Chmod(d, tempFolderPermissions); err != nil {
+		return "", newError(ErrHomeNotWritable, err, "failed to set the permissions of %s", d)
+	}
+
+	return d, nil
+}
+
+// NewTempFile creates a file in the temp folder of the okteto home. The caller must remove it when it's done.
+// The name of the file includes the pid of the process, so CleanTempDir doesn't remove the files of running processes
+func NewTempFile(prefix string) (*os.File, error) {
+	d, err := GetTempDirE()
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.TempFile(d, fmt.Sprintf("%s-%d-", prefix, os.Getpid()))
+}
+
+// CleanTempDir removes the files of the temp folder that don't belong to a running okteto process
+func CleanTempDir() error {
+	d, err := GetTempDirE()
+	if err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(d)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", d, err)
+	}
+
+	for _, e := range entries {
+		if pid, ok := tempFilePID(e.Name()); ok && (pid == os.Getpid() || processExists(pid)) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(d, e.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", filepath.Join(d, e.Name()), err)
+		}
+	}
+
+	return nil
+}
+
+// tempFilePID returns the pid included in the name of a file created by NewTempFile
+func tempFilePID(name string) (int, bool) {
+	parts := strings.Split(name, "-")
+	if len(parts) < 3 {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return 0, false
+	}
+
+	return pid, true
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestNewTempFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+
+	f, err := NewTempFile("kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if filepath.Dir(f.Name()) != internalFolder(dir, tempFolderName) {
+		t.Errorf("%s wasn't created in the temp folder", f.Name())
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(internalFolder(dir, tempFolderName))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != tempFolderPermissions {
+			t.Errorf("got permissions %s, expected %s", info.Mode().Perm(), tempFolderPermissions)
+		}
+	}
+
+	namespaces, err := ListNamespaceHomes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(namespaces) != 0 {
+		t.Errorf("the temp folder is listed as a namespace: %v", namespaces)
+	}
+}
+
+func TestCleanTempDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exists := processExists
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+		processExists = exists
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	processExists = func(pid int) bool { return pid == 12345 }
+
+	own, err := NewTempFile("own")
+	if err != nil {
+		t.Fatal(err)
+	}
+	own.Close()
+
+	tmp := GetTempDir()
+	running := filepath.Join(tmp, "running-12345-987")
+	stale := filepath.Join(tmp, "stale-54321-987")
+	unknown := filepath.Join(tmp, "unknown")
+	for _, f := range []string{running, stale, unknown} {
+		if err := ioutil.WriteFile(f, []byte("data"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := CleanTempDir(); err != nil {
+		t.Fatal(err)
+	}
+
+	for f, expected := range map[string]bool{own.Name(): true, running: true, stale: false, unknown: false} {
+		_, err := os.Stat(f)
+		if got := err == nil; got != expected {
+			t.Errorf("%s exists: %t, expected %t", f, got, expected)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// VerifyChecksum returns an ErrChecksumMismatch error if the sha256 of the file at path isn't expectedSHA256, hex encoded
+func VerifyChecksum(path, expectedSHA256 string) error {
+	expected, err := hex.DecodeString(strings.TrimSpace(expectedSHA256))
+	if err != nil || len(expected) != sha256.Size {
+		return fmt.Errorf("'%s' is not a valid sha256 checksum", expectedSHA256)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	got := h.Sum(nil)
+	if subtle.ConstantTimeCompare(got, expected) != 1 {
+		return newError(ErrChecksumMismatch, nil, "the checksum of %s is %x, expected %x", path, got, expected)
+	}
+
+	return nil
+}
+
+// ReplaceBinary replaces the running binary with the file at src, once its checksum is verified.
+// The running binary is left intact if the checksum doesn't match or the new binary can't be written
+func ReplaceBinary(src, expectedSHA256 string) error {
+	dst, err := ResolveBinaryPath()
+	if err != nil {
+		return err
+	}
+
+	return replaceBinary(src, dst, expectedSHA256, runtime.GOOS)
+}
+
+func replaceBinary(src, dst, expectedSHA256, goos string) error {
+	if err := VerifyChecksum(src, expectedSHA256); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0755)
+	if info, err := os.Stat(dst); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := copyNextTo(src, dst, perm)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	// the copy is verified again, so a file modified after the first check is never installed
+	if err := VerifyChecksum(tmp, expectedSHA256); err != nil {
+		return err
+	}
+
+	if goos != "windows" {
+		if err := os.Rename(tmp, dst); err != nil {
+			return fmt.Errorf("failed to replace %s: %w", dst, err)
+		}
+
+		return nil
+	}
+
+	// a running binary can't be overwritten on windows, but it can be renamed
+	old := dst + ".old"
+	os.Remove(old)
+	if err := os.Rename(dst, old); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", dst, err)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		if rErr := os.Rename(old, dst); rErr != nil {
+			log.Infof("failed to restore %s: %s", dst, rErr)
+		}
+
+		return fmt.Errorf("failed to replace %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// copyNextTo copies src to a temporary file in the folder of dst, so it can be renamed over dst
+func copyNextTo(src, dst string, perm os.FileMode) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := ioutil.TempFile(filepath.Dir(dst), fmt.Sprintf(".%s-", filepath.Base(dst)))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file for %s: %w", dst, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", fmt.Errorf("failed to write temporary file for %s: %w", dst, err)
+	}
+
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("failed to close temporary file for %s: %w", dst, err)
+	}
+
+	if err := os.Chmod(out.Name(), perm); err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("failed to set permissions of temporary file for %s: %w", dst, err)
+	}
+
+	return out.Name(), nil
+}
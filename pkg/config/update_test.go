@@ -0,0 +1,124 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sha256 of "okteto"
+const oktetoSHA256 = "f3e5cc8e295a9a1da35438eda77750d0b2a7560b82f87a36d0d51f3de23d2073"
+
+func TestVerifyChecksum(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "okteto")
+	if err := ioutil.WriteFile(path, []byte("okteto"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name         string
+		path         string
+		checksum     string
+		expectErr    bool
+		expectedKind error
+	}{
+		{name: "good", path: path, checksum: oktetoSHA256},
+		{name: "good-uppercase", path: path, checksum: "F3E5CC8E295A9A1DA35438EDA77750D0B2A7560B82F87A36D0D51F3DE23D2073"},
+		{name: "bad", path: path, checksum: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", expectErr: true, expectedKind: ErrChecksumMismatch},
+		{name: "not-hex", path: path, checksum: "not-a-checksum", expectErr: true},
+		{name: "too-short", path: path, checksum: "f3e5cc8e", expectErr: true},
+		{name: "missing-file", path: filepath.Join(dir, "missing"), checksum: oktetoSHA256, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChecksum(tt.path, tt.checksum)
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("expectErr: %t, got: %v", tt.expectErr, err)
+			}
+
+			if tt.expectedKind != nil && !errors.Is(err, tt.expectedKind) {
+				t.Errorf("expected '%s', got '%v'", tt.expectedKind, err)
+			}
+		})
+	}
+}
+
+func Test_replaceBinary(t *testing.T) {
+	for _, goos := range []string{"linux", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			dst := filepath.Join(dir, "okteto-bin")
+			if err := ioutil.WriteFile(dst, []byte("old"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			src := filepath.Join(dir, "download")
+			if err := ioutil.WriteFile(src, []byte("okteto"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			err = replaceBinary(src, dst, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", goos)
+			if !errors.Is(err, ErrChecksumMismatch) {
+				t.Fatalf("expected a checksum mismatch, got %v", err)
+			}
+
+			assertFileContent(t, dst, "old")
+
+			if err := replaceBinary(src, dst, oktetoSHA256, goos); err != nil {
+				t.Fatal(err)
+			}
+
+			assertFileContent(t, dst, "okteto")
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, f := range files {
+				if f.Name() != "okteto-bin" && f.Name() != "download" && f.Name() != "okteto-bin.old" {
+					t.Errorf("temporary file %s wasn't removed", f.Name())
+				}
+			}
+		})
+	}
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != expected {
+		t.Errorf("%s: got '%s', expected '%s'", path, string(b), expected)
+	}
+}
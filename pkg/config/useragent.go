@@ -0,0 +1,55 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+// GetUserAgent returns the user agent of the requests of okteto, e.g. "okteto/1.2.3 (linux/amd64)".
+// The version is "dev" for builds without a version
+func GetUserAgent() string {
+	version := VersionString
+	if version == "" {
+		version = "dev"
+	}
+
+	name := strings.TrimSuffix(GetBinaryName(), ".exe")
+	return fmt.Sprintf("%s/%s (%s/%s)", name, version, runtime.GOOS, runtime.GOARCH)
+}
+
+// WithUserAgent returns a round tripper that sets GetUserAgent in the requests that don't define a user agent
+func WithUserAgent(rt http.RoundTripper) http.RoundTripper {
+	return &userAgentTransport{rt: rt, userAgent: GetUserAgent()}
+}
+
+type userAgentTransport struct {
+	rt        http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip executes a single HTTP transaction
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.rt.RoundTrip(req)
+	}
+
+	// a round tripper must not modify the request
+	r := req.Clone(req.Context())
+	r.Header.Set("User-Agent", t.userAgent)
+	return t.rt.RoundTrip(r)
+}
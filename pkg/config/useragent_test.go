@@ -0,0 +1,97 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestGetUserAgent(t *testing.T) {
+	args := os.Args
+	version := VersionString
+	defer func() {
+		os.Args = args
+		VersionString = version
+	}()
+
+	os.Args = []string{"/usr/local/bin/okteto"}
+	var tests = []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{
+			name:     "version",
+			version:  "1.2.3",
+			expected: "okteto/1.2.3 (" + runtime.GOOS + "/" + runtime.GOARCH + ")",
+		},
+		{
+			name:     "no-version",
+			expected: "okteto/dev (" + runtime.GOOS + "/" + runtime.GOARCH + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			VersionString = tt.version
+			if got := GetUserAgent(); got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var got string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	}))
+	defer s.Close()
+
+	c := &http.Client{Transport: WithUserAgent(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got != GetUserAgent() {
+		t.Errorf("got '%s', expected '%s'", got, GetUserAgent())
+	}
+
+	if req.Header.Get("User-Agent") != "" {
+		t.Errorf("the user agent was set in the original request")
+	}
+
+	req.Header.Set("User-Agent", "custom")
+	resp, err = c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got != "custom" {
+		t.Errorf("got '%s', expected the user agent of the request", got)
+	}
+}
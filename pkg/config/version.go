@@ -0,0 +1,38 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrDevVersion is returned by GetVersion when the binary is not a release build
+var ErrDevVersion = errors.New("development version")
+
+// GetVersion returns the parsed version of the binary. It returns ErrDevVersion for non-release builds
+func GetVersion() (*semver.Version, error) {
+	if VersionString == "" || VersionString == "dev" {
+		return nil, ErrDevVersion
+	}
+
+	v, err := semver.NewVersion(VersionString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version '%s': %w", VersionString, err)
+	}
+
+	return v, nil
+}
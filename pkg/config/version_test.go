@@ -0,0 +1,64 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetVersion(t *testing.T) {
+	var tests = []struct {
+		name     string
+		version  string
+		expected string
+		err      error
+	}{
+		{name: "empty", version: "", err: ErrDevVersion},
+		{name: "dev", version: "dev", err: ErrDevVersion},
+		{name: "release", version: "1.10.2", expected: "1.10.2"},
+		{name: "prerelease", version: "1.10.2-rc.1", expected: "1.10.2-rc.1"},
+	}
+
+	current := VersionString
+	defer func() {
+		VersionString = current
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			VersionString = tt.version
+			got, err := GetVersion()
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %s, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.String() != tt.expected {
+				t.Errorf("got %s, expected %s", got.String(), tt.expected)
+			}
+		})
+	}
+
+	VersionString = "not-a-version"
+	if _, err := GetVersion(); err == nil || errors.Is(err, ErrDevVersion) {
+		t.Errorf("expected invalid version error, got %v", err)
+	}
+}
@@ -120,6 +120,51 @@ func IsTransient(err error) bool {
 	}
 }
 
+// IsUnauthorized returns true if err is caused by missing or invalid credentials
+func IsUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, ErrNotLogged),
+		strings.Contains(err.Error(), "Unauthorized"),
+		strings.Contains(err.Error(), "unauthorized"),
+		strings.Contains(err.Error(), "forbidden"),
+		strings.Contains(err.Error(), "Forbidden"):
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRetryable returns true if the operation that caused err might succeed if it's executed again
+func IsRetryable(err error) bool {
+	if err == nil || IsUnauthorized(err) {
+		return false
+	}
+
+	if IsTransient(err) {
+		return true
+	}
+
+	switch {
+	case errors.Is(err, ErrInternalServerError),
+		strings.Contains(err.Error(), "the server is currently unable to handle the request"),
+		strings.Contains(err.Error(), "an error on the server"),
+		strings.Contains(err.Error(), "the server has received too many requests"),
+		strings.Contains(err.Error(), "Internal Server Error"),
+		strings.Contains(err.Error(), "Bad Gateway"),
+		strings.Contains(err.Error(), "Service Unavailable"),
+		strings.Contains(err.Error(), "Gateway Timeout"),
+		strings.Contains(err.Error(), "Client.Timeout exceeded"),
+		strings.Contains(err.Error(), "context deadline exceeded"):
+		return true
+	default:
+		return false
+	}
+}
+
 // IsClosedNetwork returns true if the error is caused by a closed network connection
 func IsClosedNetwork(err error) bool {
 	if err == nil {
@@ -0,0 +1,46 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "connection-refused", err: fmt.Errorf("dial tcp 10.0.0.1:443: connect: connection refused"), expected: true},
+		{name: "timeout", err: fmt.Errorf("net/http: TLS handshake timeout"), expected: true},
+		{name: "internal-server-error", err: fmt.Errorf("an error on the server (\"\") has prevented the request from succeeding"), expected: true},
+		{name: "unavailable", err: fmt.Errorf("the server is currently unable to handle the request"), expected: true},
+		{name: "okteto-internal-server-error", err: fmt.Errorf("failed: %w", ErrInternalServerError), expected: true},
+		{name: "unauthorized", err: fmt.Errorf("Unauthorized"), expected: false},
+		{name: "forbidden", err: fmt.Errorf("namespaces \"ns\" is forbidden: User \"u\" cannot get resource"), expected: false},
+		{name: "not-logged", err: ErrNotLogged, expected: false},
+		{name: "not-found", err: fmt.Errorf("namespaces \"ns\" not found"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("got %t, expected %t", got, tt.expected)
+			}
+		})
+	}
+}
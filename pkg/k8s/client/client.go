@@ -14,6 +14,7 @@
 package client
 
 import (
+	okConfig "github.com/okteto/okteto/pkg/config"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -25,13 +26,23 @@ var client *kubernetes.Clientset
 var config *rest.Config
 var namespace string
 
-//GetLocal returns a kubernetes client with the local configuration. It will detect if KUBECONFIG is defined.
+//GetLocal returns a kubernetes client with the local configuration. It will detect if OKTETO_KUBECONFIG or KUBECONFIG are defined.
+//If context is empty, it uses the context set in OKTETO_CONTEXT or the current context of the kubeconfig
 func GetLocal(context string) (*kubernetes.Clientset, *rest.Config, string, error) {
 	if client == nil {
 		var err error
 
+		if context == "" {
+			context, err = okConfig.GetKubeContextE()
+			if err != nil {
+				return nil, nil, "", err
+			}
+		}
+
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.Precedence = okConfig.GetKubeConfigFiles()
 		clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			clientcmd.NewDefaultClientConfigLoadingRules(),
+			loadingRules,
 			&clientcmd.ConfigOverrides{
 				CurrentContext: context,
 				ClusterInfo:    clientcmdapi.Cluster{Server: ""},
@@ -0,0 +1,88 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/rest"
+)
+
+// clockSkewThreshold is the difference with the clock of the API server from which okteto warns the user
+const clockSkewThreshold = 10 * time.Second
+
+// ClockSkewHint is the hint shown when the local clock is skewed with the clock of the API server
+const ClockSkewHint = "Synchronize your clock (e.g. enable automatic date and time in your system settings) and try again"
+
+// CheckClockSkew returns the difference between the clock of the API server of the current context and the local clock.
+// A positive skew means the local clock is behind the server
+func CheckClockSkew(ctx context.Context) (time.Duration, error) {
+	_, cfg, _, err := GetLocal("")
+	if err != nil {
+		return 0, err
+	}
+
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the transport of the API server: %w", err)
+	}
+
+	skew, err := measureClockSkew(ctx, &http.Client{Transport: transport, Timeout: 10 * time.Second}, strings.TrimSuffix(cfg.Host, "/")+"/version", time.Now)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Infof("clock skew with %s: %s", cfg.Host, skew)
+	return skew, nil
+}
+
+// IsClockSkewed returns true if skew is big enough to make the credentials look invalid
+func IsClockSkewed(skew time.Duration) bool {
+	return skew > clockSkewThreshold || skew < -clockSkewThreshold
+}
+
+// measureClockSkew returns the difference between the Date header returned by u and the local clock.
+// The local time is taken halfway through the request, to discount the latency of the network
+func measureClockSkew(ctx context.Context, c *http.Client, u string, now func() time.Time) (time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the request to %s: %w", u, err)
+	}
+
+	start := now()
+	resp, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	end := now()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return 0, fmt.Errorf("%s didn't return its date", u)
+	}
+
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("%s returned an invalid date '%s': %w", u, date, err)
+	}
+
+	local := start.Add(end.Sub(start) / 2)
+	return serverTime.Sub(local), nil
+}
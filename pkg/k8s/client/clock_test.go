@@ -0,0 +1,104 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_measureClockSkew(t *testing.T) {
+	serverTime := time.Date(2020, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name      string
+		date      string
+		local     time.Time
+		expected  time.Duration
+		expectErr bool
+	}{
+		{
+			name:     "in-sync",
+			date:     serverTime.Format(http.TimeFormat),
+			local:    serverTime,
+			expected: 0,
+		},
+		{
+			name:     "local-behind",
+			date:     serverTime.Format(http.TimeFormat),
+			local:    serverTime.Add(-time.Minute),
+			expected: time.Minute,
+		},
+		{
+			name:     "local-ahead",
+			date:     serverTime.Format(http.TimeFormat),
+			local:    serverTime.Add(2 * time.Minute),
+			expected: -2 * time.Minute,
+		},
+		{
+			name:      "missing-date",
+			expectErr: true,
+		},
+		{
+			name:      "invalid-date",
+			date:      "yesterday",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/version" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				// the Date header is set by the server unless it's explicitly removed
+				w.Header()["Date"] = nil
+				if tt.date != "" {
+					w.Header().Set("Date", tt.date)
+				}
+			}))
+			defer s.Close()
+
+			// the request takes two seconds, the local time is taken halfway
+			calls := 0
+			now := func() time.Time {
+				calls++
+				if calls == 1 {
+					return tt.local.Add(-time.Second)
+				}
+				return tt.local.Add(time.Second)
+			}
+
+			got, err := measureClockSkew(context.Background(), s.Client(), s.URL+"/version", now)
+			if err != nil {
+				if !tt.expectErr {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if tt.expectErr {
+				t.Fatalf("expected error, got %s", got)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -105,19 +105,22 @@ func GetRevisionAnnotatedDeploymentOrFailed(ctx context.Context, dev *model.Dev,
 }
 
 //GetTranslations fills all the deployments pointed by a development container
-func GetTranslations(ctx context.Context, dev *model.Dev, d *appsv1.Deployment, c *kubernetes.Clientset) (map[string]*model.Translation, error) {
+func GetTranslations(ctx context.Context, dev *model.Dev, d *appsv1.Deployment, c kubernetes.Interface) (map[string]*model.Translation, error) {
 	result := map[string]*model.Translation{}
 	if d != nil {
 		rule := dev.ToTranslationRule(dev)
 		result[d.Name] = &model.Translation{
-			Interactive: true,
-			Name:        dev.Name,
-			Version:     model.TranslationVersion,
-			Deployment:  d,
-			Annotations: dev.Annotations,
-			Tolerations: dev.Tolerations,
-			Replicas:    *d.Spec.Replicas,
-			Rules:       []*model.TranslationRule{rule},
+			Interactive:      true,
+			Name:             dev.Name,
+			Version:          model.TranslationVersion,
+			Deployment:       d,
+			Annotations:      dev.Annotations,
+			Labels:           dev.MetadataLabels(),
+			Tolerations:      dev.Tolerations,
+			NodeSelector:     dev.NodeSelector,
+			ImagePullSecrets: dev.ImagePullSecrets,
+			Replicas:         *d.Spec.Replicas,
+			Rules:            []*model.TranslationRule{rule},
 		}
 	}
 
@@ -143,14 +146,17 @@ func loadServiceTranslations(ctx context.Context, dev *model.Dev, result map[str
 		}
 
 		result[d.Name] = &model.Translation{
-			Name:        dev.Name,
-			Interactive: false,
-			Version:     model.TranslationVersion,
-			Deployment:  d,
-			Annotations: dev.Annotations,
-			Tolerations: dev.Tolerations,
-			Replicas:    *d.Spec.Replicas,
-			Rules:       []*model.TranslationRule{rule},
+			Name:             dev.Name,
+			Interactive:      false,
+			Version:          model.TranslationVersion,
+			Deployment:       d,
+			Annotations:      dev.Annotations,
+			Labels:           s.MetadataLabels(),
+			Tolerations:      dev.Tolerations,
+			NodeSelector:     dev.NodeSelector,
+			ImagePullSecrets: dev.ImagePullSecrets,
+			Replicas:         *d.Spec.Replicas,
+			Rules:            []*model.TranslationRule{rule},
 		}
 
 	}
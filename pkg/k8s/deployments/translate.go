@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	okLabels "github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/log"
@@ -26,6 +27,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -35,6 +37,8 @@ const (
 	revisionAnnotation         = "deployment.kubernetes.io/revision"
 	//OktetoBinName name of the okteto bin init container
 	OktetoBinName = "okteto-bin"
+	//OktetoDevInitName name of the init container defined in the okteto manifest
+	OktetoDevInitName = "okteto-dev-init"
 
 	//syncthing
 	oktetoSyncSecretVolume = "okteto-sync-secret" // skipcq GSC-G101  not a secret
@@ -43,6 +47,20 @@ const (
 )
 
 var (
+	oktetoLabels = map[string]bool{
+		okLabels.DevLabel:            true,
+		okLabels.InteractiveDevLabel: true,
+		okLabels.DetachedDevLabel:    true,
+		okLabels.SyncLabel:           true,
+	}
+
+	oktetoAnnotations = map[string]bool{
+		oktetoDeploymentAnnotation:     true,
+		oktetoVersionAnnotation:        true,
+		okLabels.RevisionAnnotation:    true,
+		okLabels.TranslationAnnotation: true,
+	}
+
 	devReplicas                      int32 = 1
 	devTerminationGracePeriodSeconds int64
 	falseBoolean                     = false
@@ -99,6 +117,8 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 	setLabel(t.Deployment.Spec.Template.GetObjectMeta(), okLabels.DevLabel, "true")
 	TranslateDevAnnotations(t.Deployment.Spec.Template.GetObjectMeta(), t.Annotations)
 	TranslateDevTolerations(&t.Deployment.Spec.Template.Spec, t.Tolerations)
+	TranslateDevNodeSelector(&t.Deployment.Spec.Template.Spec, t.NodeSelector)
+	TranslateDevImagePullSecrets(&t.Deployment.Spec.Template.Spec, t.ImagePullSecrets)
 	TranslatePodAffinity(&t.Deployment.Spec.Template.Spec, t.Name)
 	t.Deployment.Spec.Template.Spec.TerminationGracePeriodSeconds = &devTerminationGracePeriodSeconds
 
@@ -112,6 +132,7 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 		}
 
 		TranslateDevContainer(devContainer, rule)
+		TranslateDevInitContainer(&t.Deployment.Spec.Template.Spec, rule)
 		TranslateOktetoVolumes(&t.Deployment.Spec.Template.Spec, rule)
 		TranslatePodSecurityContext(&t.Deployment.Spec.Template.Spec, rule.SecurityContext)
 		TranslateOktetoDevSecret(&t.Deployment.Spec.Template.Spec, t.Name, rule.Secrets)
@@ -126,6 +147,12 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 
 func commonTranslation(t *model.Translation) {
 	TranslateDevAnnotations(t.Deployment.GetObjectMeta(), t.Annotations)
+	TranslateDevLabels(t.Deployment.GetObjectMeta(), t.Labels, nil)
+	var selector map[string]string
+	if t.Deployment.Spec.Selector != nil {
+		selector = t.Deployment.Spec.Selector.MatchLabels
+	}
+	TranslateDevLabels(t.Deployment.Spec.Template.GetObjectMeta(), t.Labels, selector)
 	setAnnotation(t.Deployment.GetObjectMeta(), oktetoVersionAnnotation, okLabels.Version)
 	setLabel(t.Deployment.GetObjectMeta(), okLabels.DevLabel, "true")
 
@@ -153,18 +180,68 @@ func GetDevContainer(spec *apiv1.PodSpec, name string) *apiv1.Container {
 	return nil
 }
 
-//TranslateDevAnnotations sets the user provided annotations
+//TranslateDevAnnotations sets the user provided annotations, the annotations managed by okteto win on conflict
 func TranslateDevAnnotations(o metav1.Object, annotations map[string]string) {
 	for key, value := range annotations {
+		if oktetoAnnotations[key] {
+			log.Warnf("annotation '%s' is managed by okteto, ignoring it", key)
+			continue
+		}
 		setAnnotation(o, key, value)
 	}
 }
 
+//TranslateDevLabels sets the user provided labels. The labels managed by okteto and the labels of the deployment selector win on conflict
+func TranslateDevLabels(o metav1.Object, labels map[string]string, selector map[string]string) {
+	for key, value := range labels {
+		if oktetoLabels[key] {
+			log.Warnf("label '%s' is managed by okteto, ignoring it", key)
+			continue
+		}
+		if v, ok := selector[key]; ok && v != value {
+			log.Warnf("label '%s' is used by the deployment selector, ignoring it", key)
+			continue
+		}
+		setLabel(o, key, value)
+	}
+}
+
 //TranslateDevTolerations sets the user provided toleretions
 func TranslateDevTolerations(spec *apiv1.PodSpec, tolerations []apiv1.Toleration) {
 	spec.Tolerations = append(spec.Tolerations, tolerations...)
 }
 
+//TranslateDevNodeSelector sets the user provided node selector, the values of the manifest win on conflict
+func TranslateDevNodeSelector(spec *apiv1.PodSpec, nodeSelector map[string]string) {
+	if len(nodeSelector) == 0 {
+		return
+	}
+
+	if spec.NodeSelector == nil {
+		spec.NodeSelector = map[string]string{}
+	}
+
+	for key, value := range nodeSelector {
+		spec.NodeSelector[key] = value
+	}
+}
+
+//TranslateDevImagePullSecrets adds the user provided image pull secrets, keeping the ones of the deployment
+func TranslateDevImagePullSecrets(spec *apiv1.PodSpec, secrets []string) {
+	for _, name := range secrets {
+		found := false
+		for _, s := range spec.ImagePullSecrets {
+			if s.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, apiv1.LocalObjectReference{Name: name})
+		}
+	}
+}
+
 //TranslatePodAffinity translates the affinity of pod to be all on the same node
 func TranslatePodAffinity(spec *apiv1.PodSpec, name string) {
 	if spec.Affinity == nil {
@@ -212,12 +289,98 @@ func TranslateDevContainer(c *apiv1.Container, rule *model.TranslationRule) {
 		c.StartupProbe = nil
 	}
 
+	TranslateProbes(c, rule.Probes)
+	TranslateLifecycle(c, rule.Lifecycle)
 	TranslateResources(c, rule.Resources)
 	TranslateEnvVars(c, rule)
 	TranslateVolumeMounts(c, rule)
 	TranslateContainerSecurityContext(c, rule.SecurityContext)
 }
 
+//TranslateProbes translates the probes defined in the okteto manifest
+func TranslateProbes(c *apiv1.Container, p *model.Probes) {
+	if p == nil {
+		return
+	}
+	if p.Liveness != nil {
+		c.LivenessProbe = translateProbe(p.Liveness)
+	}
+	if p.Readiness != nil {
+		c.ReadinessProbe = translateProbe(p.Readiness)
+	}
+	if p.Startup != nil {
+		c.StartupProbe = translateProbe(p.Startup)
+	}
+}
+
+func translateProbe(p *model.Probe) *apiv1.Probe {
+	result := &apiv1.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		SuccessThreshold:    p.SuccessThreshold,
+		FailureThreshold:    p.FailureThreshold,
+	}
+
+	switch {
+	case p.HTTPGet != nil:
+		result.HTTPGet = &apiv1.HTTPGetAction{
+			Path:   p.HTTPGet.Path,
+			Port:   intstr.FromInt(p.HTTPGet.Port),
+			Host:   p.HTTPGet.Host,
+			Scheme: apiv1.URIScheme(strings.ToUpper(p.HTTPGet.Scheme)),
+		}
+	case p.TCPSocket != nil:
+		result.TCPSocket = &apiv1.TCPSocketAction{
+			Port: intstr.FromInt(p.TCPSocket.Port),
+			Host: p.TCPSocket.Host,
+		}
+	case p.Exec != nil:
+		result.Exec = &apiv1.ExecAction{
+			Command: p.Exec.Command.Values,
+		}
+	}
+
+	return result
+}
+
+//TranslateLifecycle translates the lifecycle hooks defined in the okteto manifest.
+//Without hooks in the manifest, the lifecycle of the container isn't modified
+func TranslateLifecycle(c *apiv1.Container, l *model.Lifecycle) {
+	if l == nil {
+		return
+	}
+	c.Lifecycle = &apiv1.Lifecycle{}
+	if l.PostStart != nil {
+		c.Lifecycle.PostStart = translateLifecycleHandler(l.PostStart)
+	}
+	if l.PreStop != nil {
+		c.Lifecycle.PreStop = translateLifecycleHandler(l.PreStop)
+	}
+}
+
+func translateLifecycleHandler(h *model.LifecycleHandler) *apiv1.Handler {
+	switch {
+	case h.HTTPGet != nil:
+		return &apiv1.Handler{
+			HTTPGet: &apiv1.HTTPGetAction{
+				Path:   h.HTTPGet.Path,
+				Port:   intstr.FromInt(h.HTTPGet.Port),
+				Host:   h.HTTPGet.Host,
+				Scheme: apiv1.URIScheme(strings.ToUpper(h.HTTPGet.Scheme)),
+			},
+		}
+	case h.Exec != nil:
+		return &apiv1.Handler{
+			Exec: &apiv1.ExecAction{
+				Command: h.Exec.Command.Values,
+			},
+		}
+	}
+
+	return nil
+}
+
 //TranslateResources translates the resources attached to a container
 func TranslateResources(c *apiv1.Container, r model.ResourceRequirements) {
 	if c.Resources.Requests == nil {
@@ -263,21 +426,42 @@ func TranslateResources(c *apiv1.Container, r model.ResourceRequirements) {
 
 //TranslateEnvVars translates the variables attached to a container
 func TranslateEnvVars(c *apiv1.Container, rule *model.TranslationRule) {
-	unusedDevEnvVar := map[string]string{}
+	unusedDevEnvVar := map[string]apiv1.EnvVar{}
 	for _, val := range rule.Environment {
-		unusedDevEnvVar[val.Name] = val.Value
+		unusedDevEnvVar[val.Name] = translateEnvVar(val)
 	}
 	for i, envvar := range c.Env {
 		if value, ok := unusedDevEnvVar[envvar.Name]; ok {
-			c.Env[i] = apiv1.EnvVar{Name: envvar.Name, Value: value}
+			c.Env[i] = value
 			delete(unusedDevEnvVar, envvar.Name)
 		}
 	}
 	for _, envvar := range rule.Environment {
 		if value, ok := unusedDevEnvVar[envvar.Name]; ok {
-			c.Env = append(c.Env, apiv1.EnvVar{Name: envvar.Name, Value: value})
+			c.Env = append(c.Env, value)
+		}
+	}
+}
+
+func translateEnvVar(e model.EnvVar) apiv1.EnvVar {
+	if e.ValueFrom == nil {
+		return apiv1.EnvVar{Name: e.Name, Value: e.Value}
+	}
+
+	source := &apiv1.EnvVarSource{}
+	if ref := e.ValueFrom.SecretKeyRef; ref != nil {
+		source.SecretKeyRef = &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}
+	}
+	if ref := e.ValueFrom.ConfigMapKeyRef; ref != nil {
+		source.ConfigMapKeyRef = &apiv1.ConfigMapKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
 		}
 	}
+	return apiv1.EnvVar{Name: e.Name, ValueFrom: source}
 }
 
 //TranslateVolumeMounts translates the volumes attached to a container
@@ -293,6 +477,7 @@ func TranslateVolumeMounts(c *apiv1.Container, rule *model.TranslationRule) {
 				Name:      v.Name,
 				MountPath: v.MountPath,
 				SubPath:   v.SubPath,
+				ReadOnly:  v.ReadOnly,
 			},
 		)
 	}
@@ -430,6 +615,10 @@ func TranslateContainerSecurityContext(c *apiv1.Container, s *model.SecurityCont
 		}
 	}
 
+	if s.ReadOnlyRootFilesystem != nil {
+		c.SecurityContext.ReadOnlyRootFilesystem = s.ReadOnlyRootFilesystem
+	}
+
 	if s.Capabilities == nil {
 		return
 	}
@@ -437,7 +626,9 @@ func TranslateContainerSecurityContext(c *apiv1.Container, s *model.SecurityCont
 		c.SecurityContext.Capabilities = &apiv1.Capabilities{}
 	}
 
-	c.SecurityContext.ReadOnlyRootFilesystem = nil
+	if s.ReadOnlyRootFilesystem == nil {
+		c.SecurityContext.ReadOnlyRootFilesystem = nil
+	}
 	c.SecurityContext.Capabilities.Add = append(c.SecurityContext.Capabilities.Add, s.Capabilities.Add...)
 	c.SecurityContext.Capabilities.Drop = append(c.SecurityContext.Capabilities.Drop, s.Capabilities.Drop...)
 }
@@ -473,6 +664,34 @@ func TranslateOktetoInitBinContainer(oktetoBinImageTag string, spec *apiv1.PodSp
 	spec.InitContainers = append(spec.InitContainers, c)
 }
 
+//TranslateDevInitContainer translates the init container defined in the okteto manifest
+func TranslateDevInitContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
+	if rule.InitContainer == nil {
+		return
+	}
+
+	image := rule.InitContainer.Image
+	if image == "" {
+		image = rule.Image
+	}
+
+	c := apiv1.Container{
+		Name:            OktetoDevInitName,
+		Image:           image,
+		ImagePullPolicy: rule.ImagePullPolicy,
+		Command:         rule.InitContainer.Command.Values,
+		Env:             []apiv1.EnvVar{},
+	}
+	for _, e := range rule.Environment {
+		c.Env = append(c.Env, translateEnvVar(e))
+	}
+
+	if spec.InitContainers == nil {
+		spec.InitContainers = []apiv1.Container{}
+	}
+	spec.InitContainers = append(spec.InitContainers, c)
+}
+
 //TranslateOktetoSyncSecret translates the syncthing secret container of a pod
 func TranslateOktetoSyncSecret(spec *apiv1.PodSpec, name string) {
 	if spec.Volumes == nil {
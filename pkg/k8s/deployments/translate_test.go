@@ -29,6 +29,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var (
@@ -76,7 +77,7 @@ services:
     sync:
        - worker:/src`, file.Name()))
 
-	dev, err := model.Read(manifest)
+	dev, err := model.Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -444,7 +445,7 @@ sync:
 persistentVolume:
   enabled: false`)
 
-	dev, err := model.Read(manifest)
+	dev, err := model.Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -748,13 +749,15 @@ func Test_translateResources(t *testing.T) {
 
 func Test_translateSecurityContext(t *testing.T) {
 	var trueB = true
+	var falseB = false
 
 	tests := []struct {
-		name         string
-		c            *apiv1.Container
-		s            *model.SecurityContext
-		expectedAdd  []apiv1.Capability
-		expectedDrop []apiv1.Capability
+		name             string
+		c                *apiv1.Container
+		s                *model.SecurityContext
+		expectedAdd      []apiv1.Capability
+		expectedDrop     []apiv1.Capability
+		expectedReadOnly *bool
 	}{
 		{
 			name: "single-add",
@@ -811,6 +814,22 @@ func Test_translateSecurityContext(t *testing.T) {
 			},
 			expectedAdd: []apiv1.Capability{"SYS_TRACE"},
 		},
+		{
+			name: "read-only-from-manifest",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{
+					ReadOnlyRootFilesystem: &falseB,
+				},
+			},
+			s: &model.SecurityContext{
+				ReadOnlyRootFilesystem: &trueB,
+				Capabilities: &model.Capabilities{
+					Add: []apiv1.Capability{"SYS_TRACE"},
+				},
+			},
+			expectedAdd:      []apiv1.Capability{"SYS_TRACE"},
+			expectedReadOnly: &trueB,
+		},
 	}
 
 	for _, tt := range tests {
@@ -828,8 +847,8 @@ func Test_translateSecurityContext(t *testing.T) {
 				t.Errorf("tt.c.SecurityContext.Capabilities.Drop != tt.expectedDrop. Expected: %s, Got; %s", tt.expectedDrop, tt.c.SecurityContext.Capabilities.Drop)
 			}
 
-			if tt.c.SecurityContext.ReadOnlyRootFilesystem != nil {
-				t.Errorf("ReadOnlyRootFilesystem was not removed")
+			if !reflect.DeepEqual(tt.c.SecurityContext.ReadOnlyRootFilesystem, tt.expectedReadOnly) {
+				t.Errorf("ReadOnlyRootFilesystem: expected %v, got %v", tt.expectedReadOnly, tt.c.SecurityContext.ReadOnlyRootFilesystem)
 			}
 		})
 	}
@@ -917,3 +936,380 @@ func TestTranslateOktetoVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateDevInitContainer(t *testing.T) {
+	var tests = []struct {
+		name     string
+		rule     *model.TranslationRule
+		expected []apiv1.Container
+	}{
+		{
+			name:     "no-init-container",
+			rule:     &model.TranslationRule{Image: "dev"},
+			expected: nil,
+		},
+		{
+			name: "default-image",
+			rule: &model.TranslationRule{
+				Image:           "dev",
+				ImagePullPolicy: apiv1.PullAlways,
+				InitContainer: &model.InitContainer{
+					Command: model.Command{Values: []string{"sh", "-c", "make migrate"}},
+				},
+			},
+			expected: []apiv1.Container{
+				{
+					Name:            OktetoDevInitName,
+					Image:           "dev",
+					ImagePullPolicy: apiv1.PullAlways,
+					Command:         []string{"sh", "-c", "make migrate"},
+					Env:             []apiv1.EnvVar{},
+				},
+			},
+		},
+		{
+			name: "custom-image",
+			rule: &model.TranslationRule{
+				Image:           "dev",
+				ImagePullPolicy: apiv1.PullAlways,
+				Environment:     []model.EnvVar{{Name: "key", Value: "value"}},
+				InitContainer: &model.InitContainer{
+					Image:   "busybox",
+					Command: model.Command{Values: []string{"true"}},
+				},
+			},
+			expected: []apiv1.Container{
+				{
+					Name:            OktetoDevInitName,
+					Image:           "busybox",
+					ImagePullPolicy: apiv1.PullAlways,
+					Command:         []string{"true"},
+					Env:             []apiv1.EnvVar{{Name: "key", Value: "value"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{}
+			TranslateDevInitContainer(spec, tt.rule)
+			if !reflect.DeepEqual(spec.InitContainers, tt.expected) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, spec.InitContainers)
+			}
+		})
+	}
+}
+
+func Test_TranslateDevLabels(t *testing.T) {
+	var tests = []struct {
+		name     string
+		existing map[string]string
+		labels   map[string]string
+		selector map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "empty",
+			existing: map[string]string{"app": "web"},
+			labels:   map[string]string{},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "merge",
+			existing: map[string]string{"app": "web"},
+			labels:   map[string]string{"team": "shop"},
+			expected: map[string]string{"app": "web", "team": "shop"},
+		},
+		{
+			name:     "okteto-labels-win",
+			existing: map[string]string{okLabels.DevLabel: "true"},
+			labels:   map[string]string{okLabels.DevLabel: "false", okLabels.DetachedDevLabel: "web"},
+			expected: map[string]string{okLabels.DevLabel: "true"},
+		},
+		{
+			name:     "selector-wins",
+			existing: map[string]string{"app": "web"},
+			labels:   map[string]string{"app": "api", "team": "shop"},
+			selector: map[string]string{"app": "web"},
+			expected: map[string]string{"app": "web", "team": "shop"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &metav1.ObjectMeta{Labels: tt.existing}
+			TranslateDevLabels(o, tt.labels, tt.selector)
+			if !reflect.DeepEqual(o.Labels, tt.expected) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, o.Labels)
+			}
+		})
+	}
+}
+
+func Test_TranslateDevNodeSelector(t *testing.T) {
+	var tests = []struct {
+		name         string
+		existing     map[string]string
+		nodeSelector map[string]string
+		expected     map[string]string
+	}{
+		{
+			name:     "empty",
+			existing: map[string]string{"pool": "prod"},
+			expected: map[string]string{"pool": "prod"},
+		},
+		{
+			name:         "no-existing",
+			nodeSelector: map[string]string{"pool": "dev"},
+			expected:     map[string]string{"pool": "dev"},
+		},
+		{
+			name:         "manifest-wins",
+			existing:     map[string]string{"pool": "prod", "disktype": "ssd"},
+			nodeSelector: map[string]string{"pool": "dev"},
+			expected:     map[string]string{"pool": "dev", "disktype": "ssd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{NodeSelector: tt.existing}
+			TranslateDevNodeSelector(spec, tt.nodeSelector)
+			if !reflect.DeepEqual(spec.NodeSelector, tt.expected) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, spec.NodeSelector)
+			}
+		})
+	}
+}
+
+func Test_TranslateDevImagePullSecrets(t *testing.T) {
+	var tests = []struct {
+		name     string
+		existing []apiv1.LocalObjectReference
+		secrets  []string
+		expected []apiv1.LocalObjectReference
+	}{
+		{
+			name:     "empty",
+			existing: []apiv1.LocalObjectReference{{Name: "prod-registry"}},
+			expected: []apiv1.LocalObjectReference{{Name: "prod-registry"}},
+		},
+		{
+			name:     "no-existing",
+			secrets:  []string{"dev-registry"},
+			expected: []apiv1.LocalObjectReference{{Name: "dev-registry"}},
+		},
+		{
+			name:     "merge",
+			existing: []apiv1.LocalObjectReference{{Name: "prod-registry"}},
+			secrets:  []string{"dev-registry", "prod-registry"},
+			expected: []apiv1.LocalObjectReference{{Name: "prod-registry"}, {Name: "dev-registry"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{ImagePullSecrets: tt.existing}
+			TranslateDevImagePullSecrets(spec, tt.secrets)
+			if !reflect.DeepEqual(spec.ImagePullSecrets, tt.expected) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, spec.ImagePullSecrets)
+			}
+		})
+	}
+}
+
+func Test_TranslateDevAnnotationsKeepsOktetoAnnotations(t *testing.T) {
+	o := &metav1.ObjectMeta{Annotations: map[string]string{oktetoVersionAnnotation: okLabels.Version}}
+	TranslateDevAnnotations(o, map[string]string{oktetoVersionAnnotation: "0.1", "key": "value"})
+	expected := map[string]string{oktetoVersionAnnotation: okLabels.Version, "key": "value"}
+	if !reflect.DeepEqual(o.Annotations, expected) {
+		t.Errorf("Expected \n%+v but got \n%+v", expected, o.Annotations)
+	}
+}
+
+func Test_TranslateProbes(t *testing.T) {
+	existing := &apiv1.Probe{Handler: apiv1.Handler{Exec: &apiv1.ExecAction{Command: []string{"true"}}}}
+	var tests = []struct {
+		name     string
+		rule     *model.TranslationRule
+		expected *apiv1.Container
+	}{
+		{
+			name:     "no-probes-no-healthchecks",
+			rule:     &model.TranslationRule{},
+			expected: &apiv1.Container{},
+		},
+		{
+			name:     "no-probes-healthchecks",
+			rule:     &model.TranslationRule{Healthchecks: true},
+			expected: &apiv1.Container{LivenessProbe: existing},
+		},
+		{
+			name: "probes",
+			rule: &model.TranslationRule{
+				Probes: &model.Probes{
+					Readiness: &model.Probe{
+						HTTPGet:       &model.HTTPGetProbe{Path: "/healthz", Port: 8080, Scheme: "https"},
+						PeriodSeconds: 5,
+					},
+					Startup: &model.Probe{
+						TCPSocket:        &model.TCPSocketProbe{Port: 8080},
+						FailureThreshold: 30,
+					},
+				},
+			},
+			expected: &apiv1.Container{
+				ReadinessProbe: &apiv1.Probe{
+					Handler: apiv1.Handler{
+						HTTPGet: &apiv1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080), Scheme: apiv1.URISchemeHTTPS},
+					},
+					PeriodSeconds: 5,
+				},
+				StartupProbe: &apiv1.Probe{
+					Handler: apiv1.Handler{
+						TCPSocket: &apiv1.TCPSocketAction{Port: intstr.FromInt(8080)},
+					},
+					FailureThreshold: 30,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &apiv1.Container{LivenessProbe: existing}
+			TranslateDevContainer(c, tt.rule)
+			if !reflect.DeepEqual(c.LivenessProbe, tt.expected.LivenessProbe) {
+				t.Errorf("Expected liveness \n%+v but got \n%+v", tt.expected.LivenessProbe, c.LivenessProbe)
+			}
+			if !reflect.DeepEqual(c.ReadinessProbe, tt.expected.ReadinessProbe) {
+				t.Errorf("Expected readiness \n%+v but got \n%+v", tt.expected.ReadinessProbe, c.ReadinessProbe)
+			}
+			if !reflect.DeepEqual(c.StartupProbe, tt.expected.StartupProbe) {
+				t.Errorf("Expected startup \n%+v but got \n%+v", tt.expected.StartupProbe, c.StartupProbe)
+			}
+		})
+	}
+}
+
+func Test_TranslateLifecycle(t *testing.T) {
+	existing := &apiv1.Lifecycle{PreStop: &apiv1.Handler{Exec: &apiv1.ExecAction{Command: []string{"stop.sh"}}}}
+	var tests = []struct {
+		name      string
+		lifecycle *model.Lifecycle
+		expected  *apiv1.Lifecycle
+	}{
+		{
+			name:      "no-lifecycle",
+			lifecycle: nil,
+			expected:  existing,
+		},
+		{
+			name: "lifecycle",
+			lifecycle: &model.Lifecycle{
+				PostStart: &model.LifecycleHandler{
+					Exec: &model.ExecProbe{Command: model.Command{Values: []string{"register.sh"}}},
+				},
+				PreStop: &model.LifecycleHandler{
+					HTTPGet: &model.HTTPGetProbe{Path: "/deregister", Port: 8080, Scheme: "http"},
+				},
+			},
+			expected: &apiv1.Lifecycle{
+				PostStart: &apiv1.Handler{
+					Exec: &apiv1.ExecAction{Command: []string{"register.sh"}},
+				},
+				PreStop: &apiv1.Handler{
+					HTTPGet: &apiv1.HTTPGetAction{Path: "/deregister", Port: intstr.FromInt(8080), Scheme: apiv1.URISchemeHTTP},
+				},
+			},
+		},
+		{
+			name: "only-post-start",
+			lifecycle: &model.Lifecycle{
+				PostStart: &model.LifecycleHandler{
+					Exec: &model.ExecProbe{Command: model.Command{Values: []string{"register.sh"}}},
+				},
+			},
+			expected: &apiv1.Lifecycle{
+				PostStart: &apiv1.Handler{
+					Exec: &apiv1.ExecAction{Command: []string{"register.sh"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &apiv1.Container{Lifecycle: existing}
+			TranslateDevContainer(c, &model.TranslationRule{Lifecycle: tt.lifecycle})
+			if !reflect.DeepEqual(c.Lifecycle, tt.expected) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, c.Lifecycle)
+			}
+		})
+	}
+}
+
+func Test_TranslateVolumeMountsReadOnly(t *testing.T) {
+	c := &apiv1.Container{}
+	rule := &model.TranslationRule{
+		Volumes: []model.VolumeMount{
+			{Name: "okteto", MountPath: "/app", SubPath: "src"},
+			{Name: "dataset", MountPath: "/data", SubPath: "images", ReadOnly: true},
+		},
+	}
+
+	TranslateVolumeMounts(c, rule)
+	expected := []apiv1.VolumeMount{
+		{Name: "okteto", MountPath: "/app", SubPath: "src"},
+		{Name: "dataset", MountPath: "/data", SubPath: "images", ReadOnly: true},
+	}
+
+	if !reflect.DeepEqual(c.VolumeMounts, expected) {
+		t.Errorf("Expected \n%+v but got \n%+v", expected, c.VolumeMounts)
+	}
+}
+
+func Test_TranslateEnvVarsValueFrom(t *testing.T) {
+	c := &apiv1.Container{
+		Env: []apiv1.EnvVar{
+			{Name: "TOKEN", Value: "old"},
+			{Name: "KEEP", Value: "value"},
+		},
+	}
+	rule := &model.TranslationRule{
+		Environment: []model.EnvVar{
+			{Name: "TOKEN", ValueFrom: &model.EnvVarSource{SecretKeyRef: &model.KeySelector{Name: "api", Key: "token"}}},
+			{Name: "LEVEL", ValueFrom: &model.EnvVarSource{ConfigMapKeyRef: &model.KeySelector{Name: "settings", Key: "level"}}},
+			{Name: "ENV", Value: "dev"},
+		},
+	}
+
+	TranslateEnvVars(c, rule)
+	expected := []apiv1.EnvVar{
+		{
+			Name: "TOKEN",
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "api"},
+					Key:                  "token",
+				},
+			},
+		},
+		{Name: "KEEP", Value: "value"},
+		{
+			Name: "LEVEL",
+			ValueFrom: &apiv1.EnvVarSource{
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "settings"},
+					Key:                  "level",
+				},
+			},
+		},
+		{Name: "ENV", Value: "dev"},
+	}
+
+	if !reflect.DeepEqual(c.Env, expected) {
+		t.Errorf("Expected \n%+v but got \n%+v", expected, c.Env)
+	}
+}
@@ -31,7 +31,7 @@ workdir: /app
 annotations:
   key1: value1
   key2: value2`)
-	dev, err := model.Read(manifest)
+	dev, err := model.Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,115 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const separator = "---\n"
+
+//Translate returns the kubernetes manifests applied by okteto up for a development container, without modifying the cluster.
+//The deployments are read from the cluster, the sandbox okteto up creates is used when the deployment doesn't exist,
+//and the syncthing configuration is not included
+func Translate(ctx context.Context, dev *model.Dev, c kubernetes.Interface) ([]byte, error) {
+	d, create, err := getDeployment(ctx, dev, c)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := deployments.GetTranslations(ctx, dev, d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deployments.TranslateDevMode(tr, nil, false); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(tr))
+	for name := range tr {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	objects := []runtime.Object{}
+	for _, name := range names {
+		d := tr[name].Deployment
+		d.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+		objects = append(objects, d)
+	}
+
+	if dev.PersistentVolumeEnabled() {
+		pvc := volumes.Translate(dev)
+		pvc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"}
+		objects = append(objects, pvc)
+	}
+
+	secret, err := secrets.Translate(dev, nil)
+	if err != nil {
+		return nil, err
+	}
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	objects = append(objects, secret)
+
+	if create {
+		svc := services.Translate(dev)
+		svc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+		objects = append(objects, svc)
+	}
+
+	var buf bytes.Buffer
+	for i, o := range objects {
+		b, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering the kubernetes manifests: %s", err)
+		}
+
+		if i > 0 {
+			buf.WriteString(separator)
+		}
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// getDeployment returns the deployment of dev, or the sandbox okteto up creates and true if it doesn't exist
+func getDeployment(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (*appsv1.Deployment, bool, error) {
+	d, err := deployments.Get(ctx, dev, dev.Namespace, c)
+	if err == nil {
+		return d, false, nil
+	}
+
+	if !errors.IsNotFound(err) || len(dev.Labels) > 0 {
+		return nil, false, err
+	}
+
+	return dev.GevSandbox(), true, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDeployment(name, image string) *appsv1.Deployment {
+	var replicas int32 = 1
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "n"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: name, Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+command: ["./run_web.sh"]
+initContainer:
+  image: busybox
+  command: ["sh", "-c", "echo init"]
+sync:
+  - .:/app
+services:
+  - name: worker
+    image: worker:latest
+    sync:
+      - worker:/src`)
+
+	dev, err := model.Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewSimpleClientset(newDeployment("worker", "worker:1.0"))
+	b, err := Translate(context.Background(), dev, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := strings.Split(string(b), separator)
+	if len(docs) != 5 {
+		t.Fatalf("expected 5 manifests, got %d:\n%s", len(docs), string(b))
+	}
+
+	expected := []string{"kind: Deployment", "kind: Deployment", "kind: PersistentVolumeClaim", "kind: Secret", "kind: Service"}
+	for i, kind := range expected {
+		if !strings.Contains(docs[i], kind) {
+			t.Errorf("manifest %d is not a '%s':\n%s", i, kind, docs[i])
+		}
+	}
+
+	for _, s := range []string{"name: web", "image: web:latest", "./run_web.sh", "okteto-dev-init", "image: busybox", "okteto-bin", "volumeMounts"} {
+		if !strings.Contains(docs[0], s) {
+			t.Errorf("deployment doesn't contain '%s':\n%s", s, docs[0])
+		}
+	}
+
+	if !strings.Contains(docs[1], "image: worker:latest") {
+		t.Errorf("service deployment doesn't contain its image:\n%s", docs[1])
+	}
+}
+
+func TestTranslateExistingDeployment(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:dev
+sync:
+  - .:/app`)
+
+	dev, err := model.Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewSimpleClientset(newDeployment("web", "web:1.0"))
+	b, err := Translate(context.Background(), dev, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := strings.Split(string(b), separator)
+	if len(docs) != 3 {
+		t.Fatalf("expected 3 manifests, got %d:\n%s", len(docs), string(b))
+	}
+
+	if !strings.Contains(docs[0], "app: web") || !strings.Contains(docs[0], "image: web:dev") {
+		t.Errorf("the deployment isn't translated from the existing one:\n%s", docs[0])
+	}
+
+	if strings.Contains(string(b), "kind: Service") {
+		t.Errorf("the service is rendered for an existing deployment:\n%s", string(b))
+	}
+}
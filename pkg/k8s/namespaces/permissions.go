@@ -0,0 +1,137 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Permission is an action okteto runs on the resources of a namespace
+type Permission struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+}
+
+// String returns the permission in the format used by kubectl, e.g. "create pods/exec"
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource = fmt.Sprintf("%s.%s", resource, p.Group)
+	}
+
+	if p.Subresource != "" {
+		resource = fmt.Sprintf("%s/%s", resource, p.Subresource)
+	}
+
+	return fmt.Sprintf("%s %s", p.Verb, resource)
+}
+
+var (
+	createDeployments = Permission{Verb: "create", Group: "apps", Resource: "deployments"}
+	createServices    = Permission{Verb: "create", Resource: "services"}
+	createExec        = Permission{Verb: "create", Resource: "pods", Subresource: "exec"}
+	createVolumes     = Permission{Verb: "create", Resource: "persistentvolumeclaims"}
+)
+
+// RequiredPermissions are all the permissions okteto may need in the namespace of a development container:
+// it swaps or creates the deployment, stores the syncthing credentials in a secret, persists the synced files in a volume,
+// and runs commands and forwards ports to the development container
+var RequiredPermissions = []Permission{
+	{Verb: "get", Group: "apps", Resource: "deployments"},
+	createDeployments,
+	{Verb: "update", Group: "apps", Resource: "deployments"},
+	createServices,
+	{Verb: "list", Resource: "pods"},
+	createExec,
+	{Verb: "create", Resource: "pods", Subresource: "portforward"},
+	{Verb: "create", Resource: "secrets"},
+	{Verb: "update", Resource: "secrets"},
+	createVolumes,
+}
+
+// GetRequiredPermissions returns the permissions of RequiredPermissions that dev needs.
+// create is true when the deployment of dev doesn't exist and okteto creates it
+func GetRequiredPermissions(dev *model.Dev, create bool) []Permission {
+	result := []Permission{}
+	for _, p := range RequiredPermissions {
+		switch p {
+		case createDeployments, createServices:
+			if !create {
+				continue
+			}
+		case createExec:
+			// in remote mode the commands run over ssh
+			if dev.RemoteModeEnabled() {
+				continue
+			}
+		case createVolumes:
+			if !dev.PersistentVolumeEnabled() {
+				continue
+			}
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// CheckPermissions returns an error listing the permissions the user doesn't have in namespace.
+// Permissions that can't be reviewed are skipped, so the check never blocks a user that could run okteto
+func CheckPermissions(ctx context.Context, namespace string, permissions []Permission, c kubernetes.Interface) error {
+	missing := []string{}
+	for _, p := range permissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   namespace,
+					Verb:        p.Verb,
+					Group:       p.Group,
+					Resource:    p.Resource,
+					Subresource: p.Subresource,
+				},
+			},
+		}
+
+		result, err := c.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			log.Infof("failed to review the permission to %s in namespace %s: %s", p, namespace, err)
+			continue
+		}
+
+		if !result.Status.Allowed {
+			missing = append(missing, p.String())
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return errors.UserError{
+		E:    fmt.Errorf("you don't have the permissions okteto needs in namespace '%s': %s", namespace, strings.Join(missing, ", ")),
+		Hint: "Ask your cluster administrator to grant you these permissions and try again",
+	}
+}
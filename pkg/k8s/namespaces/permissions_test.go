@@ -0,0 +1,161 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	var tests = []struct {
+		name      string
+		denied    map[string]bool
+		failures  bool
+		expectErr string
+	}{
+		{
+			name: "allowed",
+		},
+		{
+			name:      "missing",
+			denied:    map[string]bool{"create pods/exec": true, "create persistentvolumeclaims": true},
+			expectErr: "you don't have the permissions okteto needs in namespace 'test': create pods/exec, create persistentvolumeclaims",
+		},
+		{
+			name:     "review-fails",
+			failures: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if tt.failures {
+					return true, nil, fmt.Errorf("unavailable")
+				}
+
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attrs := review.Spec.ResourceAttributes
+				if attrs.Namespace != "test" {
+					t.Errorf("the permission was reviewed in namespace '%s'", attrs.Namespace)
+				}
+
+				p := Permission{Verb: attrs.Verb, Group: attrs.Group, Resource: attrs.Resource, Subresource: attrs.Subresource}
+				review.Status.Allowed = !tt.denied[p.String()]
+				return true, review, nil
+			})
+
+			err := CheckPermissions(context.Background(), "test", RequiredPermissions, c)
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if _, ok := err.(errors.UserError); !ok {
+				t.Errorf("expected a user error, got %T", err)
+			}
+
+			if err.Error() != tt.expectErr {
+				t.Errorf("got '%s', expected '%s'", err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestGetRequiredPermissions(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dev      *model.Dev
+		create   bool
+		excluded []Permission
+	}{
+		{
+			name:   "create",
+			dev:    &model.Dev{RemotePort: 22000},
+			create: true,
+			excluded: []Permission{
+				createExec,
+			},
+		},
+		{
+			name: "existing-deployment",
+			dev:  &model.Dev{RemotePort: 22000},
+			excluded: []Permission{
+				createDeployments,
+				createServices,
+				createExec,
+			},
+		},
+		{
+			name: "no-persistent-volume",
+			dev:  &model.Dev{RemotePort: 22000, PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: false}},
+			excluded: []Permission{
+				createDeployments,
+				createServices,
+				createExec,
+				createVolumes,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetRequiredPermissions(tt.dev, tt.create)
+			if len(got) != len(RequiredPermissions)-len(tt.excluded) {
+				t.Errorf("got %d permissions, expected %d", len(got), len(RequiredPermissions)-len(tt.excluded))
+			}
+
+			for _, p := range got {
+				for _, e := range tt.excluded {
+					if p == e {
+						t.Errorf("%s is required", p)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionString(t *testing.T) {
+	var tests = []struct {
+		permission Permission
+		expected   string
+	}{
+		{permission: Permission{Verb: "create", Group: "apps", Resource: "deployments"}, expected: "create deployments.apps"},
+		{permission: Permission{Verb: "create", Resource: "pods", Subresource: "exec"}, expected: "create pods/exec"},
+		{permission: Permission{Verb: "list", Resource: "pods"}, expected: "list pods"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.permission.String(); got != tt.expected {
+			t.Errorf("got '%s', expected '%s'", got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pods
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// streamLogsRetryInterval is the time to wait before reconnecting to the logs of a container
+var streamLogsRetryInterval = 2 * time.Second
+
+// writeLogLine writes a line of the logs of a container
+var writeLogLine = func(line string) {
+	log.Println(line)
+}
+
+// StreamLogs follows the logs of a container and writes each line through the log package, prefixed with prefix.
+// It reconnects when the container restarts, and streams the logs of the previous container while the current one isn't running.
+// It returns when ctx is done or the pod can't be retrieved
+func StreamLogs(ctx context.Context, namespace, podName, container, prefix string, c kubernetes.Interface) error {
+	// last is the restart count of the last container instance whose logs were streamed
+	var last int32 = -1
+	var since *metav1.Time
+
+	for {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+		}
+
+		if container == "" && len(pod.Spec.Containers) > 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+
+		status := getContainerStatus(pod, container)
+		switch {
+		case status == nil:
+			log.Infof("container %s of pod %s/%s has no status yet", container, namespace, podName)
+		case status.State.Running != nil:
+			if status.RestartCount != last {
+				last = status.RestartCount
+				since = nil
+			}
+
+			opts := &apiv1.PodLogOptions{Container: container, Follow: true, SinceTime: since}
+			if err := streamContainerLogs(ctx, namespace, podName, opts, prefix, c); err != nil {
+				log.Infof("logs of container %s of pod %s/%s interrupted: %s", container, namespace, podName, err)
+			}
+
+			// lines written before the stream ended aren't repeated when reconnecting to the same container
+			now := metav1.Now()
+			since = &now
+		case status.RestartCount > 0 && status.RestartCount-1 > last:
+			last = status.RestartCount - 1
+			since = nil
+			log.Infof("container %s of pod %s/%s isn't running, streaming the logs of the previous container", container, namespace, podName)
+			opts := &apiv1.PodLogOptions{Container: container, Previous: true}
+			if err := streamContainerLogs(ctx, namespace, podName, opts, prefix, c); err != nil {
+				log.Infof("failed to get the logs of the previous container %s of pod %s/%s: %s", container, namespace, podName, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Debug("call to pods.StreamLogs cancelled")
+			return ctx.Err()
+		case <-time.After(streamLogsRetryInterval):
+		}
+	}
+}
+
+func streamContainerLogs(ctx context.Context, namespace, podName string, opts *apiv1.PodLogOptions, prefix string, c kubernetes.Interface) error {
+	stream, err := c.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			writeLogLine(fmt.Sprintf("%s %s", prefix, strings.TrimSuffix(line, "\n")))
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func getContainerStatus(pod *apiv1.Pod, container string) *apiv1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == container {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pods
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStreamLogs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		status   apiv1.ContainerStatus
+		previous bool
+	}{
+		{
+			name: "running",
+			status: apiv1.ContainerStatus{
+				Name:  "dev",
+				State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+			},
+		},
+		{
+			name: "crash-loop",
+			status: apiv1.ContainerStatus{
+				Name:         "dev",
+				RestartCount: 3,
+				State:        apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			},
+			previous: true,
+		},
+	}
+
+	defer func(interval time.Duration, write func(string)) {
+		streamLogsRetryInterval = interval
+		writeLogLine = write
+	}(streamLogsRetryInterval, writeLogLine)
+	streamLogsRetryInterval = time.Millisecond
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+				Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Name: "dev"}}},
+				Status:     apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{tt.status}},
+			}
+			c := fake.NewSimpleClientset(ns, pod)
+
+			var opts []*apiv1.PodLogOptions
+			c.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "log" {
+					opts = append(opts, action.(k8stesting.GenericAction).GetValue().(*apiv1.PodLogOptions))
+				}
+				return false, nil, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			var lines []string
+			writeLogLine = func(line string) {
+				lines = append(lines, line)
+				cancel()
+			}
+
+			if err := StreamLogs(ctx, "test", "api", "", "[api]", c); err != context.Canceled {
+				t.Fatalf("expected the context error, got %v", err)
+			}
+
+			if len(lines) != 1 || lines[0] != "[api] fake logs" {
+				t.Errorf("unexpected lines: %v", lines)
+			}
+
+			if len(opts) != 1 {
+				t.Fatalf("expected one logs request, got %d", len(opts))
+			}
+
+			if opts[0].Container != "dev" || opts[0].Previous != tt.previous || opts[0].Follow == tt.previous {
+				t.Errorf("unexpected log options: %+v", opts[0])
+			}
+		})
+	}
+}
+
+func TestStreamLogsPodNotFound(t *testing.T) {
+	c := fake.NewSimpleClientset(ns)
+	if err := StreamLogs(context.Background(), "test", "api", "dev", "[api]", c); err == nil {
+		t.Fatal("expected an error when the pod doesn't exist")
+	}
+}
@@ -16,10 +16,8 @@ package secrets
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"strings"
 
-	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/syncthing"
@@ -54,28 +52,10 @@ func Create(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, s *syn
 	if err != nil {
 		return fmt.Errorf("error generating syncthing configuration: %s", err)
 	}
-	data := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: secretName,
-			Labels: map[string]string{
-				labels.DevLabel: "true",
-			},
-		},
-		Type: v1.SecretTypeOpaque,
-		Data: map[string][]byte{
-			"config.xml": config,
-			"cert.pem":   []byte(certPEM),
-			"key.pem":    []byte(keyPEM),
-		},
-	}
 
-	for _, s := range dev.Secrets {
-		content, err := ioutil.ReadFile(s.LocalPath)
-		if err != nil {
-			return fmt.Errorf("error reading secret '%s': %s", s.LocalPath, err)
-		}
-
-		data.Data[s.GetKeyName()] = content
+	data, err := Translate(dev, config)
+	if err != nil {
+		return err
 	}
 
 	if sct.Name == "" {
@@ -0,0 +1,56 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/okteto/okteto/pkg/k8s/labels"
+	"github.com/okteto/okteto/pkg/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//Translate returns the okteto secret for a development container. The syncthing configuration is skipped if config is nil
+func Translate(dev *model.Dev, config []byte) (*v1.Secret, error) {
+	data := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetSecretName(dev),
+			Labels: map[string]string{
+				labels.DevLabel: "true",
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"cert.pem": []byte(certPEM),
+			"key.pem":  []byte(keyPEM),
+		},
+	}
+
+	if config != nil {
+		data.Data["config.xml"] = config
+	}
+
+	for _, s := range dev.Secrets {
+		content, err := ioutil.ReadFile(s.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret '%s': %s", s.LocalPath, err)
+		}
+
+		data.Data[s.GetKeyName()] = content
+	}
+
+	return data, nil
+}
@@ -32,7 +32,7 @@ func CreateDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset) err
 		return fmt.Errorf("error getting kubernetes service: %s", err)
 	}
 
-	s := translate(dev)
+	s := Translate(dev)
 	sClient := c.CoreV1().Services(dev.Namespace)
 
 	if old.Name == "" {
@@ -25,7 +25,8 @@ const (
 	oktetoAutoIngressAnnotation = "dev.okteto.com/auto-ingress"
 )
 
-func translate(dev *model.Dev) *apiv1.Service {
+//Translate returns the default k8s service for a development container
+func Translate(dev *model.Dev) *apiv1.Service {
 	annotations := map[string]string{}
 	if len(dev.Services) == 0 {
 		annotations[oktetoAutoIngressAnnotation] = "true"
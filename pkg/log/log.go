@@ -17,12 +17,16 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -45,12 +49,16 @@ var (
 type logger struct {
 	out  *logrus.Logger
 	file *logrus.Entry
+	json bool
 }
 
 var log = &logger{
 	out: logrus.New(),
 }
 
+var interactive bool
+var iOnce sync.Once
+
 func init() {
 	if runtime.GOOS == "windows" {
 		successSymbol = color.New(color.BgGreen, color.FgBlack).Sprint(" + ")
@@ -58,9 +66,23 @@ func init() {
 }
 
 // Init configures the logger for the package to use.
-func Init(level logrus.Level, dir, version string) {
+// OKTETO_LOG_LEVEL takes precedence over level when it's defined.
+// Logs are also appended to logPath, which is rotated when it reaches 1 MB. No file log is written if logPath is empty
+func Init(level logrus.Level, logPath, version string) {
 	log.out.SetOutput(os.Stdout)
 	log.out.SetLevel(level)
+	if l, ok := getEnvLevel(); ok {
+		log.out.SetLevel(l)
+	}
+
+	if strings.ToLower(os.Getenv("OKTETO_LOG_FORMAT")) == "json" {
+		setJSONFormat()
+	}
+
+	if logPath == "" {
+		log.file = nil
+		return
+	}
 
 	fileLogger := logrus.New()
 	fileLogger.SetFormatter(&logrus.TextFormatter{
@@ -68,7 +90,6 @@ func Init(level logrus.Level, dir, version string) {
 		FullTimestamp: true,
 	})
 
-	logPath := filepath.Join(dir, "okteto.log")
 	rolling := getRollingLog(logPath)
 	fileLogger.SetOutput(rolling)
 	fileLogger.SetLevel(logrus.DebugLevel)
@@ -77,6 +98,36 @@ func Init(level logrus.Level, dir, version string) {
 	log.file = fileLogger.WithFields(logrus.Fields{"action": actionID, "version": version})
 }
 
+// setJSONFormat writes every log line as a JSON object with the level, message and timestamp
+func setJSONFormat() {
+	log.json = true
+	log.out.SetFormatter(&logrus.JSONFormatter{
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyMsg:  "message",
+			logrus.FieldKeyTime: "timestamp",
+		},
+	})
+	SetInteractive(false)
+}
+
+// IsJSON returns if logs are written as JSON objects
+func IsJSON() bool {
+	return log.json
+}
+
+// writeJSON writes a message meant for the user, whatever the level of the logger is
+func writeJSON(level logrus.Level, format string, args ...interface{}) {
+	e := logrus.NewEntry(log.out)
+	e.Time = time.Now()
+	e.Level = level
+	e.Message = fmt.Sprintf(format, args...)
+	b, err := log.out.Formatter.Format(e)
+	if err != nil {
+		return
+	}
+	log.out.Out.Write(b)
+}
+
 func getRollingLog(path string) io.Writer {
 	return &lumberjack.Logger{
 		Filename:   path,
@@ -95,6 +146,65 @@ func SetLevel(level string) {
 	}
 }
 
+// GetDefaultLevel returns the level defined in OKTETO_LOG_LEVEL, or defaultLevel if it's not defined
+func GetDefaultLevel(defaultLevel string) string {
+	if l, ok := getEnvLevel(); ok {
+		return l.String()
+	}
+	return defaultLevel
+}
+
+func getEnvLevel() (logrus.Level, bool) {
+	v := os.Getenv("OKTETO_LOG_LEVEL")
+	if v == "" {
+		return logrus.InfoLevel, false
+	}
+
+	l, err := logrus.ParseLevel(v)
+	if err != nil {
+		return logrus.InfoLevel, false
+	}
+
+	return l, true
+}
+
+// IsInteractive returns if the output is a terminal where spinners can be drawn.
+// It's false if stderr is not a terminal, OKTETO_DISABLE_SPINNER is true or okteto runs in a CI
+func IsInteractive() bool {
+	iOnce.Do(func() {
+		interactive = isInteractive(terminal.IsTerminal(int(os.Stderr.Fd())), os.Getenv)
+	})
+	return interactive
+}
+
+// SetInteractive overrides the detected value of IsInteractive
+func SetInteractive(value bool) {
+	iOnce.Do(func() {})
+	interactive = value
+}
+
+func isInteractive(isTerminal bool, getenv func(string) string) bool {
+	if !isTerminal {
+		return false
+	}
+
+	if v := getenv("OKTETO_DISABLE_SPINNER"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			Yellow("'%s' is not a valid value for environment variable OKTETO_DISABLE_SPINNER", v)
+		}
+		if disabled {
+			return false
+		}
+	}
+
+	if v := getenv("CI"); v != "" && v != "false" {
+		return false
+	}
+
+	return true
+}
+
 // Debug writes a debug-level log
 func Debug(args ...interface{}) {
 	log.out.Debug(args...)
@@ -127,6 +237,22 @@ func Infof(format string, args ...interface{}) {
 	}
 }
 
+// Warn writes a warn-level log
+func Warn(args ...interface{}) {
+	log.out.Warn(args...)
+	if log.file != nil {
+		log.file.Warn(args...)
+	}
+}
+
+// Warnf writes a warn-level log with a format
+func Warnf(format string, args ...interface{}) {
+	log.out.Warnf(format, args...)
+	if log.file != nil {
+		log.file.Warnf(format, args...)
+	}
+}
+
 // Error writes a error-level log
 func Error(args ...interface{}) {
 	log.out.Error(args...)
@@ -154,12 +280,20 @@ func Fatalf(format string, args ...interface{}) {
 
 // Yellow writes a line in yellow
 func Yellow(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.WarnLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintln(color.Output, yellowString(format, args...))
 }
 
 // Green writes a line in green
 func Green(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.InfoLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintln(color.Output, greenString(format, args...))
 }
@@ -171,30 +305,50 @@ func BlueString(format string, args ...interface{}) string {
 
 // Success prints a message with the success symbol first, and the text in green
 func Success(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.InfoLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintf(color.Output, "%s %s\n", successSymbol, greenString(format, args...))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func Information(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.InfoLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintf(color.Output, "%s %s\n", informationSymbol, blueString(format, args...))
 }
 
 // Hint prints a message with the text in blue
 func Hint(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.InfoLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintf(color.Output, "%s\n", blueString(format, args...))
 }
 
 // Fail prints a message with the error symbol first, and the text in red
 func Fail(format string, args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.ErrorLevel, format, args...)
+		return
+	}
 	log.out.Infof(format, args...)
 	fmt.Fprintf(color.Output, "%s %s\n", errorSymbol, redString(format, args...))
 }
 
 // Println writes a line with colors
 func Println(args ...interface{}) {
+	if log.json {
+		writeJSON(logrus.InfoLevel, "%s", strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+		return
+	}
 	log.out.Info(args...)
 	fmt.Fprintln(color.Output, args...)
 }
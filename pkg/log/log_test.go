@@ -0,0 +1,130 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func Test_isInteractive(t *testing.T) {
+	var tests = []struct {
+		name       string
+		isTerminal bool
+		env        map[string]string
+		expected   bool
+	}{
+		{name: "terminal", isTerminal: true, expected: true},
+		{name: "no-terminal", isTerminal: false, expected: false},
+		{name: "disable-spinner", isTerminal: true, env: map[string]string{"OKTETO_DISABLE_SPINNER": "true"}, expected: false},
+		{name: "enable-spinner", isTerminal: true, env: map[string]string{"OKTETO_DISABLE_SPINNER": "false"}, expected: true},
+		{name: "ci", isTerminal: true, env: map[string]string{"CI": "true"}, expected: false},
+		{name: "ci-false", isTerminal: true, env: map[string]string{"CI": "false"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isInteractive(tt.isTerminal, func(k string) string { return tt.env[k] })
+			if got != tt.expected {
+				t.Errorf("got %t, expected %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetInteractive(t *testing.T) {
+	SetInteractive(true)
+	if !IsInteractive() {
+		t.Error("interactive mode wasn't forced")
+	}
+
+	SetInteractive(false)
+	if IsInteractive() {
+		t.Error("non-interactive mode wasn't forced")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log.out.SetOutput(&buf)
+	log.out.SetLevel(logrus.WarnLevel)
+	defer func() {
+		log.json = false
+		log.out.SetFormatter(&logrus.TextFormatter{})
+		log.out.SetOutput(os.Stdout)
+	}()
+
+	setJSONFormat()
+	if IsInteractive() {
+		t.Error("the spinner wasn't disabled in json mode")
+	}
+
+	Infof("hidden %s", "message")
+	Success("deployed %s", "api")
+	Errorf("failed %d times", 2)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+
+	expected := []struct{ level, message string }{
+		{level: "info", message: "deployed api"},
+		{level: "error", message: "failed 2 times"},
+	}
+
+	for i, l := range lines {
+		record := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(l), &record); err != nil {
+			t.Fatalf("line %d is not json: %s", i, l)
+		}
+
+		if record["level"] != expected[i].level || record["message"] != expected[i].message {
+			t.Errorf("got %v, expected %+v", record, expected[i])
+		}
+
+		if _, ok := record["timestamp"]; !ok {
+			t.Errorf("line %d doesn't have a timestamp: %s", i, l)
+		}
+	}
+}
+
+func TestGetDefaultLevel(t *testing.T) {
+	defer os.Unsetenv("OKTETO_LOG_LEVEL")
+
+	var tests = []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "unset", value: "", expected: "warn"},
+		{name: "debug", value: "debug", expected: "debug"},
+		{name: "upper", value: "ERROR", expected: "error"},
+		{name: "invalid", value: "verbose", expected: "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("OKTETO_LOG_LEVEL", tt.value)
+			if got := GetDefaultLevel("warn"); got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadBuild feeds the 'build' section to the build info of 'image', used by the build layer.
+// 'build' also accepts a string with the build context
+func (dev *Dev) loadBuild() error {
+	if dev.Build == nil {
+		return nil
+	}
+
+	if dev.Build.Name != "" {
+		if dev.Build.Context != "" {
+			return fmt.Errorf("'build.name' is not supported, use the field 'image' to name the image built by okteto")
+		}
+		dev.Build.Context = dev.Build.Name
+		dev.Build.Name = ""
+	}
+
+	i := dev.Image
+	if i.Context != "" || i.Dockerfile != "" || i.Target != "" || len(i.Args) > 0 || len(i.CacheFrom) > 0 {
+		return fmt.Errorf("'build' can't be combined with the build fields of 'image', define them in 'build' and use 'image' to name the image")
+	}
+
+	setBuildDefaults(dev.Build)
+	i.Context = dev.Build.Context
+	i.Dockerfile = dev.Build.Dockerfile
+	i.Target = dev.Build.Target
+	i.Args = dev.Build.Args
+	i.CacheFrom = dev.Build.CacheFrom
+	return nil
+}
+
+// validateBuild checks that the build context is a folder, and that the dockerfile is a file inside it
+func validateBuild(b *BuildInfo) error {
+	if b == nil {
+		return nil
+	}
+
+	info, err := os.Stat(b.Context)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("'build.context' must be an existing folder: '%s'", b.Context)
+	}
+
+	info, err = os.Stat(b.Dockerfile)
+	if err != nil || !info.Mode().IsRegular() {
+		return fmt.Errorf("'build.dockerfile' must be an existing file: '%s'", b.Dockerfile)
+	}
+
+	rel, err := filepath.Rel(b.Context, b.Dockerfile)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("'build.dockerfile' must be inside 'build.context': '%s'", b.Dockerfile)
+	}
+
+	return nil
+}
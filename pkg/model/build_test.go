@@ -0,0 +1,164 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_loadBuild(t *testing.T) {
+	var tests = []struct {
+		name      string
+		manifest  string
+		expected  *BuildInfo
+		expectErr bool
+	}{
+		{
+			name:     "no-build",
+			manifest: "name: api\nimage: okteto/golang:1\n",
+			expected: &BuildInfo{Name: "okteto/golang:1", Context: ".", Dockerfile: "Dockerfile"},
+		},
+		{
+			name:     "build",
+			manifest: "name: api\nimage: okteto/api:dev\nbuild:\n  context: api\n  dockerfile: api/Dockerfile.dev\n  target: dev\n  args:\n    - VERSION=${BUILD_VERSION}\n",
+			expected: &BuildInfo{Name: "okteto/api:dev", Context: "api", Dockerfile: "api/Dockerfile.dev", Target: "dev", Args: []EnvVar{{Name: "VERSION", Value: "1.2.3"}}},
+		},
+		{
+			name:     "build-without-image",
+			manifest: "name: api\nbuild:\n  context: api\n",
+			expected: &BuildInfo{Context: "api", Dockerfile: filepath.Join("api", "Dockerfile")},
+		},
+		{
+			name:     "build-context-shorthand",
+			manifest: "name: api\nbuild: api\n",
+			expected: &BuildInfo{Context: "api", Dockerfile: filepath.Join("api", "Dockerfile")},
+		},
+		{
+			name:      "build-and-image-build-fields",
+			manifest:  "name: api\nimage:\n  name: okteto/api:dev\n  context: .\nbuild:\n  context: api\n",
+			expectErr: true,
+		},
+		{
+			name:      "build-name",
+			manifest:  "name: api\nbuild:\n  name: okteto/api:dev\n  context: api\n",
+			expectErr: true,
+		},
+	}
+
+	os.Setenv("BUILD_VERSION", "1.2.3")
+	defer os.Unsetenv("BUILD_VERSION")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Parse([]byte(tt.manifest))
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(dev.Image, tt.expected) {
+				t.Errorf("got %+v, expected %+v", dev.Image, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_validateBuild(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	context := filepath.Join(dir, "api")
+	if err := os.MkdirAll(filepath.Join(context, "build"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{filepath.Join(context, "Dockerfile"), filepath.Join(context, "build", "Dockerfile.dev"), filepath.Join(dir, "Dockerfile")} {
+		if err := ioutil.WriteFile(f, []byte("FROM alpine"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tests = []struct {
+		name      string
+		build     *BuildInfo
+		expectErr bool
+	}{
+		{
+			name:      "nil",
+			build:     nil,
+			expectErr: false,
+		},
+		{
+			name:      "default-dockerfile",
+			build:     &BuildInfo{Context: context, Dockerfile: filepath.Join(context, "Dockerfile")},
+			expectErr: false,
+		},
+		{
+			name:      "nested-dockerfile",
+			build:     &BuildInfo{Context: context, Dockerfile: filepath.Join(context, "build", "Dockerfile.dev")},
+			expectErr: false,
+		},
+		{
+			name:      "missing-context",
+			build:     &BuildInfo{Context: filepath.Join(dir, "missing"), Dockerfile: filepath.Join(dir, "missing", "Dockerfile")},
+			expectErr: true,
+		},
+		{
+			name:      "context-is-a-file",
+			build:     &BuildInfo{Context: filepath.Join(dir, "Dockerfile"), Dockerfile: filepath.Join(dir, "Dockerfile")},
+			expectErr: true,
+		},
+		{
+			name:      "missing-dockerfile",
+			build:     &BuildInfo{Context: context, Dockerfile: filepath.Join(context, "Dockerfile.prod")},
+			expectErr: true,
+		},
+		{
+			name:      "dockerfile-is-a-folder",
+			build:     &BuildInfo{Context: context, Dockerfile: filepath.Join(context, "build")},
+			expectErr: true,
+		},
+		{
+			name:      "dockerfile-outside-context",
+			build:     &BuildInfo{Context: context, Dockerfile: filepath.Join(dir, "Dockerfile")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBuild(tt.build)
+			if tt.expectErr && err == nil {
+				t.Error("didn't got the expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
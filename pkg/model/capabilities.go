@@ -0,0 +1,96 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// linuxCapabilities are the capabilities of the linux kernel, as kubernetes expects them: without the CAP_ prefix
+var linuxCapabilities = map[apiv1.Capability]bool{
+	"ALL":                true,
+	"AUDIT_CONTROL":      true,
+	"AUDIT_READ":         true,
+	"AUDIT_WRITE":        true,
+	"BLOCK_SUSPEND":      true,
+	"BPF":                true,
+	"CHECKPOINT_RESTORE": true,
+	"CHOWN":              true,
+	"DAC_OVERRIDE":       true,
+	"DAC_READ_SEARCH":    true,
+	"FOWNER":             true,
+	"FSETID":             true,
+	"IPC_LOCK":           true,
+	"IPC_OWNER":          true,
+	"KILL":               true,
+	"LEASE":              true,
+	"LINUX_IMMUTABLE":    true,
+	"MAC_ADMIN":          true,
+	"MAC_OVERRIDE":       true,
+	"MKNOD":              true,
+	"NET_ADMIN":          true,
+	"NET_BIND_SERVICE":   true,
+	"NET_BROADCAST":      true,
+	"NET_RAW":            true,
+	"PERFMON":            true,
+	"SETFCAP":            true,
+	"SETGID":             true,
+	"SETPCAP":            true,
+	"SETUID":             true,
+	"SYSLOG":             true,
+	"SYS_ADMIN":          true,
+	"SYS_BOOT":           true,
+	"SYS_CHROOT":         true,
+	"SYS_MODULE":         true,
+	"SYS_NICE":           true,
+	"SYS_PACCT":          true,
+	"SYS_PTRACE":         true,
+	"SYS_RAWIO":          true,
+	"SYS_RESOURCE":       true,
+	"SYS_TIME":           true,
+	"SYS_TTY_CONFIG":     true,
+	"WAKE_ALARM":         true,
+}
+
+func validateCapabilities(c *Capabilities) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := validateCapabilityNames("add", c.Add); err != nil {
+		return err
+	}
+
+	return validateCapabilityNames("drop", c.Drop)
+}
+
+func validateCapabilityNames(field string, capabilities []apiv1.Capability) error {
+	for _, c := range capabilities {
+		if linuxCapabilities[c] {
+			continue
+		}
+
+		normalized := apiv1.Capability(strings.TrimPrefix(strings.ToUpper(string(c)), "CAP_"))
+		if linuxCapabilities[normalized] {
+			return fmt.Errorf("'securityContext.capabilities.%s' contains an invalid capability '%s', did you mean '%s'?", field, c, normalized)
+		}
+
+		return fmt.Errorf("'securityContext.capabilities.%s' contains an unknown capability '%s'", field, c)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func Test_validateCapabilities(t *testing.T) {
+	var tests = []struct {
+		name         string
+		capabilities *Capabilities
+		expectedErr  string
+	}{
+		{
+			name:         "nil",
+			capabilities: nil,
+		},
+		{
+			name:         "valid",
+			capabilities: &Capabilities{Add: []apiv1.Capability{"SYS_PTRACE", "NET_ADMIN"}, Drop: []apiv1.Capability{"ALL"}},
+		},
+		{
+			name:         "unknown",
+			capabilities: &Capabilities{Add: []apiv1.Capability{"SYS_TRACE"}},
+			expectedErr:  "unknown capability 'SYS_TRACE'",
+		},
+		{
+			name:         "prefix",
+			capabilities: &Capabilities{Drop: []apiv1.Capability{"CAP_NET_RAW"}},
+			expectedErr:  "did you mean 'NET_RAW'?",
+		},
+		{
+			name:         "lower-case",
+			capabilities: &Capabilities{Add: []apiv1.Capability{"sys_ptrace"}},
+			expectedErr:  "did you mean 'SYS_PTRACE'?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCapabilities(tt.capabilities)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("expected error containing '%s', got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
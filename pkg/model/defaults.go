@@ -0,0 +1,128 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultsManifestPath is the user-level manifest merged under every manifest, so common values don't have to be copied across projects.
+// It's set by the cli, an empty value disables it
+var DefaultsManifestPath string
+
+// applyDefaults merges the user-level defaults manifest under the manifest b.
+// Maps are merged recursively, and scalars and lists of the manifest replace the ones of the defaults
+func applyDefaults(b []byte) ([]byte, error) {
+	if DefaultsManifestPath == "" {
+		return b, nil
+	}
+
+	d, err := ioutil.ReadFile(DefaultsManifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", DefaultsManifestPath, err)
+	}
+
+	defaults := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(d, &defaults); err != nil {
+		return nil, fmt.Errorf("%s is not a valid manifest: %w", DefaultsManifestPath, err)
+	}
+
+	if len(defaults) == 0 {
+		return b, nil
+	}
+
+	if _, err := getConfigVersion(defaults); err != nil {
+		return nil, fmt.Errorf("%s is not a valid manifest: %w", DefaultsManifestPath, err)
+	}
+	// the version of the defaults doesn't apply to the manifest
+	delete(defaults, manifestVersionField)
+
+	manifest := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		// Parse reports a better error
+		return b, nil
+	}
+
+	fromDefaults := []string{}
+	merged := mergeManifests(defaults, manifest, "", &fromDefaults)
+	if len(fromDefaults) > 0 {
+		sort.Strings(fromDefaults)
+		log.Debugf("fields loaded from %s: %s", DefaultsManifestPath, strings.Join(fromDefaults, ", "))
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// checkDefaults returns the error of the defaults manifest, with the line numbers of the file
+func checkDefaults() error {
+	if DefaultsManifestPath == "" {
+		return nil
+	}
+
+	d, err := ioutil.ReadFile(DefaultsManifestPath)
+	if err != nil {
+		// applyDefaults reports it
+		return nil
+	}
+
+	if err := yaml.UnmarshalStrict(d, &Dev{}); err != nil {
+		return fmt.Errorf("%s is not a valid manifest: %w", DefaultsManifestPath, manifestError(err))
+	}
+
+	return nil
+}
+
+// mergeManifests returns the fields of defaults overridden by the fields of manifest. Null fields of manifest don't override defaults.
+// The paths of the fields taken from defaults are added to fromDefaults
+func mergeManifests(defaults, manifest map[interface{}]interface{}, prefix string, fromDefaults *[]string) map[interface{}]interface{} {
+	result := map[interface{}]interface{}{}
+	for k, v := range manifest {
+		if v != nil {
+			result[k] = v
+		}
+	}
+
+	for k, dv := range defaults {
+		path := fmt.Sprintf("%v", k)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		mv, ok := result[k]
+		if !ok {
+			result[k] = dv
+			*fromDefaults = append(*fromDefaults, path)
+			continue
+		}
+
+		dm, dIsMap := dv.(map[interface{}]interface{})
+		mm, mIsMap := mv.(map[interface{}]interface{})
+		if dIsMap && mIsMap {
+			result[k] = mergeManifests(dm, mm, path, fromDefaults)
+		}
+	}
+
+	return result
+}
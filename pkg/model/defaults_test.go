@@ -0,0 +1,216 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_mergeManifests(t *testing.T) {
+	defaults := map[interface{}]interface{}{
+		"image":   "okteto/golang:1",
+		"command": []interface{}{"bash"},
+		"persistentVolume": map[interface{}]interface{}{
+			"enabled": true,
+			"size":    "10Gi",
+		},
+		"resources": map[interface{}]interface{}{
+			"limits": map[interface{}]interface{}{"cpu": "1", "memory": "1Gi"},
+		},
+	}
+
+	manifest := map[interface{}]interface{}{
+		"name":    "api",
+		"image":   nil,
+		"command": []interface{}{"sh"},
+		"persistentVolume": map[interface{}]interface{}{
+			"size": "20Gi",
+		},
+		"resources": "invalid",
+	}
+
+	expected := map[interface{}]interface{}{
+		"name":    "api",
+		"image":   "okteto/golang:1",
+		"command": []interface{}{"sh"},
+		"persistentVolume": map[interface{}]interface{}{
+			"enabled": true,
+			"size":    "20Gi",
+		},
+		"resources": "invalid",
+	}
+
+	fromDefaults := []string{}
+	got := mergeManifests(defaults, manifest, "", &fromDefaults)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+
+	if len(fromDefaults) != 2 {
+		t.Errorf("got %v, expected image and persistentVolume.enabled", fromDefaults)
+	}
+}
+
+func TestGetServiceWithDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defaults := filepath.Join(dir, "defaults.yml")
+	if err := ioutil.WriteFile(defaults, []byte(`image: okteto/golang:1
+persistentVolume:
+  size: 10Gi
+  storageClass: ssd
+environment:
+  - FROM_DEFAULTS=true
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte(`name: api
+persistentVolume:
+  size: 20Gi
+environment:
+  - FROM_PROJECT=true
+sync:
+  - .:/app
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	DefaultsManifestPath = defaults
+	defer func() {
+		DefaultsManifestPath = ""
+	}()
+
+	dev, err := GetService(manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Image.Name != "okteto/golang:1" {
+		t.Errorf("the image wasn't loaded from the defaults: %s", dev.Image.Name)
+	}
+
+	if dev.PersistentVolumeSize() != "20Gi" {
+		t.Errorf("the size of the manifest didn't win: %s", dev.PersistentVolumeSize())
+	}
+
+	if dev.PersistentVolumeStorageClass() != "ssd" {
+		t.Errorf("the storage class wasn't loaded from the defaults: %s", dev.PersistentVolumeStorageClass())
+	}
+
+	if len(dev.Environment) != 1 || dev.Environment[0].Name != "FROM_PROJECT" {
+		t.Errorf("the environment of the manifest didn't replace the defaults: %+v", dev.Environment)
+	}
+}
+
+func TestGetServiceWithInvalidDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defaults := filepath.Join(dir, "defaults.yml")
+	if err := ioutil.WriteFile(defaults, []byte("image: [okteto"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("name: api\nsync:\n  - .:/app\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	DefaultsManifestPath = defaults
+	defer func() {
+		DefaultsManifestPath = ""
+	}()
+
+	_, err = GetService(manifest, "")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), defaults) {
+		t.Errorf("the error doesn't mention the defaults file: %s", err)
+	}
+}
+
+func TestGetServiceWithUnknownDefaultsField(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defaults := filepath.Join(dir, "defaults.yml")
+	if err := ioutil.WriteFile(defaults, []byte("timeout: 1m\ntimeouts: 2m\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("name: api\nsync:\n  - .:/app\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	DefaultsManifestPath = defaults
+	defer func() {
+		DefaultsManifestPath = ""
+	}()
+
+	_, err = GetService(manifest, "")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), defaults) || !strings.Contains(err.Error(), "line 2: field timeouts not found") {
+		t.Errorf("the error doesn't point to the line of the defaults file: %s", err)
+	}
+}
+
+func TestGetServiceWithoutDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("name: api\nimage: okteto/node:12\nsync:\n  - .:/app\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	DefaultsManifestPath = filepath.Join(dir, "missing.yml")
+	defer func() {
+		DefaultsManifestPath = ""
+	}()
+
+	dev, err := GetService(manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Image.Name != "okteto/node:12" {
+		t.Errorf("got %s, expected okteto/node:12", dev.Image.Name)
+	}
+}
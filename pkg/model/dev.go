@@ -14,8 +14,12 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -27,6 +31,7 @@ import (
 	"time"
 
 	"github.com/a8m/envsubst"
+	"github.com/docker/distribution/reference"
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/log"
@@ -34,7 +39,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
@@ -90,6 +99,14 @@ const (
 	authorizedKeysPath = "/var/okteto/remote/authorized_keys"
 
 	syncFieldDocsURL = "https://okteto.com/docs/reference/manifest#sync-string-required"
+
+	// placeholder for "$$" while the environment is expanded
+	escapedDollar = "\x00"
+
+	// maxNameLength is the maximum length of a kubernetes label value
+	maxNameLength = 63
+
+	defaultInferredName = "dev"
 )
 
 var (
@@ -101,6 +118,9 @@ var (
 	// ValidKubeNameRegex is the regex to validate a kubernetes resource name
 	ValidKubeNameRegex = regexp.MustCompile(`[^a-z0-9\-]+`)
 
+	// manifestTypeSuffix is the go type the yaml errors refer to, it isn't meaningful to the user
+	manifestTypeSuffix = regexp.MustCompile(`\s*in type [\w.]+$`)
+
 	rootUser int64
 
 	// DevReplicas is the number of dev replicas
@@ -113,21 +133,32 @@ var (
 
 //Dev represents a development container
 type Dev struct {
+	Version              int                   `json:"version,omitempty" yaml:"version,omitempty"`
 	Name                 string                `json:"name" yaml:"name"`
 	Labels               map[string]string     `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Annotations          map[string]string     `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Metadata             *Metadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Tolerations          []apiv1.Toleration    `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	NodeSelector         map[string]string     `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
 	Context              string                `json:"context,omitempty" yaml:"context,omitempty"`
 	Namespace            string                `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Timeout              Duration              `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Container            string                `json:"container,omitempty" yaml:"container,omitempty"`
 	EmptyImage           bool                  `json:"-" yaml:"-"`
 	Image                *BuildInfo            `json:"image,omitempty" yaml:"image,omitempty"`
+	Build                *BuildInfo            `json:"build,omitempty" yaml:"build,omitempty"`
 	Push                 *BuildInfo            `json:"-" yaml:"push,omitempty"`
 	ImagePullPolicy      apiv1.PullPolicy      `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	ImagePullSecrets     []string              `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
+	InitContainer        *InitContainer        `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	Environment          []EnvVar              `json:"environment,omitempty" yaml:"environment,omitempty"`
+	EnvFiles             []string              `json:"envFiles,omitempty" yaml:"envFiles,omitempty"`
 	Secrets              []Secret              `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	Command              Command               `json:"command,omitempty" yaml:"command,omitempty"`
 	Healthchecks         bool                  `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
+	Probes               *Probes               `json:"probes,omitempty" yaml:"probes,omitempty"`
+	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Restart              *Restart              `json:"restart,omitempty" yaml:"restart,omitempty"`
 	WorkDir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
 	MountPath            string                `json:"mountpath,omitempty" yaml:"mountpath,omitempty"`
 	SubPath              string                `json:"subpath,omitempty" yaml:"subpath,omitempty"`
@@ -146,9 +177,11 @@ type Dev struct {
 	PersistentVolumeInfo *PersistentVolumeInfo `json:"persistentVolume,omitempty" yaml:"persistentVolume,omitempty"`
 }
 
-//Command represents the start command of a development contaianer
+//Command represents the start command of a development contaianer.
+//A string with arguments is run with 'sh -c' (shell form), a list is used verbatim (exec form)
 type Command struct {
 	Values []string
+	shell  bool
 }
 
 //Args represents the args of a development contaianer
@@ -156,6 +189,9 @@ type Args struct {
 	Values []string
 }
 
+//Duration represents a duration of the manifest: a Go duration (e.g. "1m30s") or a plain integer interpreted as seconds
+type Duration time.Duration
+
 // BuildInfo represents the build info to generate an image
 type BuildInfo struct {
 	Name       string   `yaml:"name,omitempty"`
@@ -166,6 +202,12 @@ type BuildInfo struct {
 	Args       []EnvVar `yaml:"args,omitempty"`
 }
 
+// InitContainer represents a command executed before the development container starts
+type InitContainer struct {
+	Image   string  `json:"image,omitempty" yaml:"image,omitempty"`
+	Command Command `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
 // Volume represents a volume in the development container
 type Volume struct {
 	LocalPath  string
@@ -177,10 +219,18 @@ type Sync struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Ignore         []string     `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+	Gitignore      bool         `json:"gitignore,omitempty" yaml:"gitignore,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+// Metadata represents the labels added to the development deployment and its pods.
+// They are kept apart from 'labels', that selects the deployment to develop
+type Metadata struct {
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
 // SyncFolder represents a sync folder in the development container
 type SyncFolder struct {
 	LocalPath  string
@@ -192,21 +242,23 @@ type ExternalVolume struct {
 	Name      string
 	SubPath   string
 	MountPath string
+	ReadOnly  bool `json:",omitempty"`
 }
 
 // PersistentVolumeInfo info about the persistent volume
 type PersistentVolumeInfo struct {
-	Enabled      bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Enabled      bool   `json:"enabled,omitempty" yaml:"enabled"`
 	StorageClass string `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
 	Size         string `json:"size,omitempty" yaml:"size,omitempty"`
 }
 
 // SecurityContext represents a pod security context
 type SecurityContext struct {
-	RunAsUser    *int64        `json:"runAsUser,omitempty" yaml:"runAsUser,omitempty"`
-	RunAsGroup   *int64        `json:"runAsGroup,omitempty" yaml:"runAsGroup,omitempty"`
-	FSGroup      *int64        `json:"fsGroup,omitempty" yaml:"fsGroup,omitempty"`
-	Capabilities *Capabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	RunAsUser              *int64        `json:"runAsUser,omitempty" yaml:"runAsUser,omitempty"`
+	RunAsGroup             *int64        `json:"runAsGroup,omitempty" yaml:"runAsGroup,omitempty"`
+	FSGroup                *int64        `json:"fsGroup,omitempty" yaml:"fsGroup,omitempty"`
+	ReadOnlyRootFilesystem *bool         `json:"readOnlyRootFilesystem,omitempty" yaml:"readOnlyRootFilesystem,omitempty"`
+	Capabilities           *Capabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
 }
 
 // Capabilities sets the linux capabilities of a container
@@ -215,10 +267,12 @@ type Capabilities struct {
 	Drop []apiv1.Capability `json:"drop,omitempty" yaml:"drop,omitempty"`
 }
 
-// EnvVar represents an environment value. When loaded, it will expand from the current env
+// EnvVar represents an environment value. When loaded, it will expand from the current env.
+// If ValueFrom is set, the value is read from a secret or a config map of the cluster
 type EnvVar struct {
-	Name  string `yaml:"name,omitempty"`
-	Value string `yaml:"value,omitempty"`
+	Name      string        `yaml:"name,omitempty"`
+	Value     string        `yaml:"value,omitempty"`
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty" yaml:"valueFrom,omitempty"`
 }
 
 // Secret represents a development secret
@@ -228,7 +282,8 @@ type Secret struct {
 	Mode       int32
 }
 
-// Reverse represents a remote forward port
+// Reverse represents a remote forward port.
+// Its local port can't be the local port of a Forward, or the traffic would be sent back to the development container
 type Reverse struct {
 	Remote int
 	Local  int
@@ -245,21 +300,103 @@ type ResourceList map[apiv1.ResourceName]resource.Quantity
 
 //Get returns a Dev object from a given file
 func Get(devPath string) (*Dev, error) {
-	b, err := ioutil.ReadFile(devPath)
+	return GetService(devPath, "")
+}
+
+//GetService returns the Dev object called name from a given file.
+//If name is empty, the file must define a single development container
+func GetService(devPath, name string) (*Dev, error) {
+	f, err := os.Open(devPath)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
-	dev, err := Read(b)
+	devDir, err := filepath.Abs(filepath.Dir(devPath))
 	if err != nil {
 		return nil, err
 	}
 
+	return read(f, devDir, filepath.Join(devDir, filepath.Base(devPath)), name, false)
+}
+
+//Read returns a Dev object from a reader, local paths are resolved from the current folder
+func Read(r io.Reader) (*Dev, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return read(r, cwd, "", "", true)
+}
+
+func read(r io.Reader, devDir, manifestPath, name string, skipMissingSyncFolders bool) (*Dev, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	original := b
+	b, err = loadIncludes(b, devDir, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b, selected, err := selectDev(b, name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = applyDefaults(b)
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := Parse(b)
+	if err != nil {
+		if string(b) != string(original) {
+			// the steps above regenerate the manifest, the error is reported on the lines of the files of the user
+			if err := checkManifest(original, selected); err != nil {
+				return nil, err
+			}
+
+			if err := checkDefaults(); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, err
+	}
+
+	if dev.Name == "" {
+		dev.Name = selected
+	}
+
+	if dev.Name == "" {
+		dev.Name = InferName(devDir)
+	}
+
+	if name != "" && selected == "" && dev.Name != name {
+		return nil, fmt.Errorf("'%s' is not defined in your manifest, available development containers: %s", name, dev.Name)
+	}
+
+	if err := dev.migrate(); err != nil {
+		return nil, err
+	}
+
 	if err := dev.translateDeprecatedVolumeFields(); err != nil {
 		return nil, err
 	}
 
-	if err := dev.loadAbsPaths(devPath); err != nil {
+	if err := dev.loadEnvFiles(devDir); err != nil {
+		return nil, err
+	}
+
+	dev.loadAbsPaths(devDir)
+
+	if skipMissingSyncFolders {
+		dev.removeMissingSyncFolders()
+	} else if err := dev.validateSyncFoldersExist(); err != nil {
 		return nil, err
 	}
 
@@ -267,13 +404,14 @@ func Get(devPath string) (*Dev, error) {
 		return nil, err
 	}
 
+	dev.Forward = append(dev.Forward, dev.servicesForwards()...)
 	dev.computeParentSyncFolder()
 
 	return dev, nil
 }
 
-//Read reads an okteto manifests
-func Read(bytes []byte) (*Dev, error) {
+//Parse parses an okteto manifest without validating it
+func Parse(bytes []byte) (*Dev, error) {
 	dev := &Dev{
 		Image:       &BuildInfo{},
 		Push:        &BuildInfo{},
@@ -289,23 +427,7 @@ func Read(bytes []byte) (*Dev, error) {
 
 	if bytes != nil {
 		if err := yaml.UnmarshalStrict(bytes, dev); err != nil {
-			if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
-				var sb strings.Builder
-				_, _ = sb.WriteString("Invalid manifest:\n")
-				l := strings.Split(err.Error(), "\n")
-				for i := 1; i < len(l); i++ {
-					e := strings.TrimSuffix(l[i], "in type model.Dev")
-					e = strings.TrimSpace(e)
-					_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e))
-				}
-
-				_, _ = sb.WriteString("    See https://okteto.com/docs/reference/manifest for details")
-				return nil, errors.New(sb.String())
-			}
-
-			msg := strings.Replace(err.Error(), "yaml: unmarshal errors:", "invalid manifest:", 1)
-			msg = strings.TrimSuffix(msg, "in type model.Dev")
-			return nil, errors.New(msg)
+			return nil, manifestError(err)
 		}
 	}
 
@@ -333,20 +455,40 @@ func Read(bytes []byte) (*Dev, error) {
 	return dev, nil
 }
 
-func (dev *Dev) loadAbsPaths(devPath string) error {
-	devDir, err := filepath.Abs(filepath.Dir(devPath))
-	if err != nil {
-		return err
+// manifestError returns the error of decoding a manifest in a format readable by the user
+func manifestError(err error) error {
+	if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
+		var sb strings.Builder
+		_, _ = sb.WriteString("Invalid manifest:\n")
+		l := strings.Split(err.Error(), "\n")
+		for i := 1; i < len(l); i++ {
+			e := manifestTypeSuffix.ReplaceAllString(l[i], "")
+			e = strings.TrimSpace(e)
+			_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e))
+		}
+
+		_, _ = sb.WriteString("    See https://okteto.com/docs/reference/manifest for details")
+		return errors.New(sb.String())
 	}
+
+	msg := strings.Replace(err.Error(), "yaml: unmarshal errors:", "invalid manifest:", 1)
+	msg = manifestTypeSuffix.ReplaceAllString(msg, "")
+	return errors.New(msg)
+}
+
+func (dev *Dev) loadAbsPaths(devDir string) {
 	dev.Image.Context = loadAbsPath(devDir, dev.Image.Context)
 	dev.Image.Dockerfile = loadAbsPath(devDir, dev.Image.Dockerfile)
 	dev.Push.Context = loadAbsPath(devDir, dev.Push.Context)
 	dev.Push.Dockerfile = loadAbsPath(devDir, dev.Push.Dockerfile)
+	if dev.Build != nil {
+		dev.Build.Context = loadAbsPath(devDir, dev.Build.Context)
+		dev.Build.Dockerfile = loadAbsPath(devDir, dev.Build.Dockerfile)
+	}
 	dev.loadVolumeAbsPaths(devDir)
 	for _, s := range dev.Services {
 		s.loadVolumeAbsPaths(devDir)
 	}
-	return nil
 }
 
 func (dev *Dev) loadVolumeAbsPaths(folder string) {
@@ -381,6 +523,9 @@ func (dev *Dev) expandEnvVars() error {
 	if err := dev.loadLabels(); err != nil {
 		return err
 	}
+	if err := dev.loadInitContainer(); err != nil {
+		return err
+	}
 
 	return dev.loadImage()
 }
@@ -388,7 +533,7 @@ func (dev *Dev) expandEnvVars() error {
 func (dev *Dev) loadName() error {
 	var err error
 	if len(dev.Name) > 0 {
-		dev.Name, err = ExpandEnv(dev.Name)
+		dev.Name, err = expandEnvField("name", dev.Name)
 		if err != nil {
 			return err
 		}
@@ -399,7 +544,7 @@ func (dev *Dev) loadName() error {
 func (dev *Dev) loadNamespace() error {
 	var err error
 	if len(dev.Namespace) > 0 {
-		dev.Namespace, err = ExpandEnv(dev.Namespace)
+		dev.Namespace, err = expandEnvField("namespace", dev.Namespace)
 		if err != nil {
 			return err
 		}
@@ -410,7 +555,7 @@ func (dev *Dev) loadNamespace() error {
 func (dev *Dev) loadContext() error {
 	var err error
 	if len(dev.Context) > 0 {
-		dev.Context, err = ExpandEnv(dev.Context)
+		dev.Context, err = expandEnvField("context", dev.Context)
 		if err != nil {
 			return err
 		}
@@ -421,7 +566,33 @@ func (dev *Dev) loadContext() error {
 func (dev *Dev) loadLabels() error {
 	var err error
 	for i := range dev.Labels {
-		dev.Labels[i], err = ExpandEnv(dev.Labels[i])
+		dev.Labels[i], err = expandEnvField(fmt.Sprintf("labels.%s", i), dev.Labels[i])
+		if err != nil {
+			return err
+		}
+	}
+	metadataLabels := dev.MetadataLabels()
+	for i := range metadataLabels {
+		metadataLabels[i], err = expandEnvField(fmt.Sprintf("metadata.labels.%s", i), metadataLabels[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MetadataLabels returns the labels of 'metadata.labels', or nil if they aren't defined
+func (dev *Dev) MetadataLabels() map[string]string {
+	if dev.Metadata == nil {
+		return nil
+	}
+	return dev.Metadata.Labels
+}
+
+func (dev *Dev) loadInitContainer() error {
+	var err error
+	if dev.InitContainer != nil && len(dev.InitContainer.Image) > 0 {
+		dev.InitContainer.Image, err = expandEnvField("initContainer.image", dev.InitContainer.Image)
 		if err != nil {
 			return err
 		}
@@ -435,7 +606,7 @@ func (dev *Dev) loadImage() error {
 		dev.Image = &BuildInfo{}
 	}
 	if len(dev.Image.Name) > 0 {
-		dev.Image.Name, err = ExpandEnv(dev.Image.Name)
+		dev.Image.Name, err = expandEnvField("image", dev.Image.Name)
 		if err != nil {
 			return err
 		}
@@ -450,6 +621,9 @@ func (dev *Dev) setDefaults() error {
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
 	}
+	if err := dev.loadBuild(); err != nil {
+		return err
+	}
 	setBuildDefaults(dev.Image)
 	setBuildDefaults(dev.Push)
 
@@ -469,6 +643,7 @@ func (dev *Dev) setDefaults() error {
 		dev.SSHServerPort = oktetoDefaultSSHServerPort
 	}
 	dev.setRunAsUserDefaults(dev)
+	dev.setSyncRemotePathDefaults()
 
 	if os.Getenv("OKTETO_RESCAN_INTERVAL") != "" {
 		rescanInterval, err := strconv.Atoi(os.Getenv("OKTETO_RESCAN_INTERVAL"))
@@ -496,7 +671,10 @@ func (dev *Dev) setDefaults() error {
 		s.Namespace = ""
 		s.Context = ""
 		s.setRunAsUserDefaults(dev)
-		s.Forward = make([]Forward, 0)
+		s.setSyncRemotePathDefaults()
+		if s.Forward == nil {
+			s.Forward = make([]Forward, 0)
+		}
 		s.Reverse = make([]Reverse, 0)
 		s.Secrets = make([]Secret, 0)
 		s.Services = make([]*Dev, 0)
@@ -550,14 +728,97 @@ func (dev *Dev) validate() error {
 		return fmt.Errorf("'subpath' is not supported in the main dev container")
 	}
 
+	if dev.Namespace != "" {
+		if errs := validation.IsDNS1123Label(dev.Namespace); len(errs) > 0 {
+			return fmt.Errorf("'namespace' is not a valid namespace name: %s", strings.Join(errs, ", "))
+		}
+	}
+
 	if err := validatePullPolicy(dev.ImagePullPolicy); err != nil {
 		return err
 	}
 
+	if err := validateImagePullSecrets(dev.ImagePullSecrets); err != nil {
+		return err
+	}
+
+	if err := validateMetadata(dev.Labels, dev.MetadataLabels(), dev.Annotations); err != nil {
+		return err
+	}
+
+	if err := validateScheduling(dev.NodeSelector, dev.Tolerations); err != nil {
+		return err
+	}
+
+	if err := validateEnvironment(dev.Environment); err != nil {
+		return err
+	}
+
 	if err := validateSecrets(dev.Secrets); err != nil {
 		return err
 	}
 
+	if err := validateImage("image", dev.Image); err != nil {
+		return err
+	}
+
+	if err := validateBuild(dev.Build); err != nil {
+		return err
+	}
+
+	if err := validateWorkDir(dev.WorkDir); err != nil {
+		return err
+	}
+
+	if dev.Sync.RescanInterval < 0 {
+		return fmt.Errorf("'sync.rescanInterval' must be a positive duration")
+	}
+
+	if err := validateSyncIgnore(dev.Sync.Ignore); err != nil {
+		return err
+	}
+
+	if err := validateCommand(dev.Command); err != nil {
+		return err
+	}
+
+	if err := validateSecurityContext(dev.SecurityContext); err != nil {
+		return err
+	}
+
+	if err := validateInitContainer(dev.InitContainer); err != nil {
+		return err
+	}
+
+	if err := validateProbes(dev.Probes); err != nil {
+		return err
+	}
+
+	if err := validateLifecycle(dev.Lifecycle); err != nil {
+		return err
+	}
+
+	if err := validateRestart(dev.Restart); err != nil {
+		return err
+	}
+
+	if err := validateResources(dev.Resources); err != nil {
+		return err
+	}
+
+	if err := dev.validateServices(); err != nil {
+		return err
+	}
+
+	forwards := append(append([]Forward{}, dev.Forward...), dev.servicesForwards()...)
+	if err := validateForwards(forwards); err != nil {
+		return err
+	}
+
+	if err := validateReverses(dev.Reverse, forwards); err != nil {
+		return err
+	}
+
 	if err := dev.validatePersistentVolume(); err != nil {
 		return err
 	}
@@ -570,10 +831,16 @@ func (dev *Dev) validate() error {
 		return err
 	}
 
-	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
+	if size, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil || size.Sign() <= 0 {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
 	}
 
+	if sc := dev.PersistentVolumeStorageClass(); sc != "" {
+		if errs := validation.IsDNS1123Subdomain(sc); len(errs) > 0 {
+			return fmt.Errorf("'persistentVolume.storageClass' is not a valid storage class name: %s", strings.Join(errs, ", "))
+		}
+	}
+
 	if dev.SSHServerPort <= 0 {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
@@ -582,6 +849,33 @@ func (dev *Dev) validate() error {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
 		}
+		if err := validateMetadata(s.Labels, s.MetadataLabels(), s.Annotations); err != nil {
+			return err
+		}
+		if err := validateImage(fmt.Sprintf("services[%s].image", s.Name), s.Image); err != nil {
+			return err
+		}
+		if err := validateWorkDir(s.WorkDir); err != nil {
+			return err
+		}
+		if err := validateCommand(s.Command); err != nil {
+			return err
+		}
+		if err := validateSecurityContext(s.SecurityContext); err != nil {
+			return err
+		}
+		if err := validateInitContainer(s.InitContainer); err != nil {
+			return err
+		}
+		if err := validateProbes(s.Probes); err != nil {
+			return err
+		}
+		if err := validateLifecycle(s.Lifecycle); err != nil {
+			return err
+		}
+		if err := validateResources(s.Resources); err != nil {
+			return err
+		}
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
@@ -590,6 +884,80 @@ func (dev *Dev) validate() error {
 	return nil
 }
 
+// validateServices checks that every service identifies a deployment with a unique name, and syncs or forwards something
+func (dev *Dev) validateServices() error {
+	names := map[string]bool{}
+	for i, s := range dev.Services {
+		if s.Name == "" && len(s.Labels) == 0 {
+			return fmt.Errorf("'name' is required for services[%d]", i)
+		}
+
+		id := s.Name
+		if id == "" {
+			id = fmt.Sprintf("services[%d]", i)
+		} else {
+			if s.Name == dev.Name {
+				return fmt.Errorf("service '%s' has the same name as the main development container", s.Name)
+			}
+			if names[s.Name] {
+				return fmt.Errorf("service '%s' is defined more than once", s.Name)
+			}
+			names[s.Name] = true
+		}
+
+		if s.Build != nil {
+			return fmt.Errorf("'build' is not supported in service '%s'", id)
+		}
+
+		if err := validateEnvironment(s.Environment); err != nil {
+			return fmt.Errorf("service '%s': %w", id, err)
+		}
+
+		if s.Version != 0 {
+			return fmt.Errorf("'version' is not supported in service '%s', define it at the top of your manifest", id)
+		}
+
+		if s.Timeout != 0 {
+			return fmt.Errorf("'timeout' is not supported in service '%s', define it at the top of your manifest", id)
+		}
+
+		if s.Restart != nil {
+			return fmt.Errorf("'restart' is not supported in service '%s'", id)
+		}
+
+		if s.Sync.HasIgnores() {
+			return fmt.Errorf("'sync.ignore' is not supported in service '%s', define it at the top of your manifest", id)
+		}
+
+		if len(s.Sync.Folders) == 0 && len(s.Forward) == 0 {
+			return fmt.Errorf("service '%s' must define 'sync' or 'forward'", id)
+		}
+
+		for _, f := range s.Forward {
+			if !f.Service && s.Name == "" {
+				return fmt.Errorf("'name' is required to forward ports of service '%s'", id)
+			}
+		}
+	}
+	return nil
+}
+
+// servicesForwards returns the port forwards of the services.
+// 'localPort:remotePort' forwards to the kubernetes service with the name of the service
+func (dev *Dev) servicesForwards() []Forward {
+	forwards := []Forward{}
+	for _, s := range dev.Services {
+		for _, f := range s.Forward {
+			if !f.Service {
+				f.Service = true
+				f.ServiceName = s.Name
+			}
+			forwards = append(forwards, f)
+		}
+	}
+	return forwards
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -601,6 +969,164 @@ func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	return nil
 }
 
+func validateImagePullSecrets(secrets []string) error {
+	names := map[string]bool{}
+	for i, s := range secrets {
+		if errs := validation.IsDNS1123Subdomain(s); len(errs) > 0 {
+			return fmt.Errorf("'imagePullSecrets[%d]' is not a valid secret name: %s", i, strings.Join(errs, ", "))
+		}
+		if names[s] {
+			return fmt.Errorf("'imagePullSecrets' contains '%s' more than once", s)
+		}
+		names[s] = true
+	}
+	return nil
+}
+
+func validateMetadata(labels, metadataLabels, annotations map[string]string) error {
+	if errs := metav1validation.ValidateLabels(labels, field.NewPath("labels")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	if errs := metav1validation.ValidateLabels(metadataLabels, field.NewPath("metadata", "labels")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	if errs := apivalidation.ValidateAnnotations(annotations, field.NewPath("annotations")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+func validateScheduling(nodeSelector map[string]string, tolerations []apiv1.Toleration) error {
+	if errs := metav1validation.ValidateLabels(nodeSelector, field.NewPath("nodeSelector")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	for i, t := range tolerations {
+		if err := validateToleration(t); err != nil {
+			return fmt.Errorf("'tolerations[%d]' is not valid: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func validateToleration(t apiv1.Toleration) error {
+	if t.Key != "" {
+		if errs := validation.IsQualifiedName(t.Key); len(errs) > 0 {
+			return fmt.Errorf("invalid key '%s': %s", t.Key, strings.Join(errs, ", "))
+		}
+	}
+	switch t.Operator {
+	case "", apiv1.TolerationOpEqual:
+		if t.Key == "" {
+			return fmt.Errorf("'key' is required when 'operator' is '%s'", apiv1.TolerationOpEqual)
+		}
+		if errs := validation.IsValidLabelValue(t.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid value '%s': %s", t.Value, strings.Join(errs, ", "))
+		}
+	case apiv1.TolerationOpExists:
+		if t.Value != "" {
+			return fmt.Errorf("'value' must be empty when 'operator' is '%s'", apiv1.TolerationOpExists)
+		}
+	default:
+		return fmt.Errorf("unsupported operator '%s', use '%s' or '%s'", t.Operator, apiv1.TolerationOpEqual, apiv1.TolerationOpExists)
+	}
+	switch t.Effect {
+	case "", apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("unsupported effect '%s', use '%s', '%s' or '%s'", t.Effect, apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute)
+	}
+	if t.TolerationSeconds != nil && t.Effect != apiv1.TaintEffectNoExecute {
+		return fmt.Errorf("'tolerationSeconds' requires the effect '%s'", apiv1.TaintEffectNoExecute)
+	}
+	return nil
+}
+
+func validateWorkDir(workdir string) error {
+	if workdir != "" && !strings.HasPrefix(workdir, "/") {
+		return fmt.Errorf("'workdir' must be an absolute path: '%s'", workdir)
+	}
+	return nil
+}
+
+func validateSecurityContext(s *SecurityContext) error {
+	if s == nil {
+		return nil
+	}
+	ids := []struct {
+		field string
+		value *int64
+	}{
+		{field: "runAsUser", value: s.RunAsUser},
+		{field: "runAsGroup", value: s.RunAsGroup},
+		{field: "fsGroup", value: s.FSGroup},
+	}
+	for _, id := range ids {
+		if id.value != nil && *id.value < 0 {
+			return fmt.Errorf("'securityContext.%s' must be a non-negative integer", id.field)
+		}
+	}
+	return validateCapabilities(s.Capabilities)
+}
+
+func validateCommand(c Command) error {
+	if c.Values == nil {
+		return nil
+	}
+	if len(c.Values) == 0 {
+		return fmt.Errorf("'command' cannot be empty")
+	}
+	if strings.TrimSpace(c.Values[0]) == "" {
+		return fmt.Errorf("the first element of 'command' cannot be empty")
+	}
+	return nil
+}
+
+func validateInitContainer(c *InitContainer) error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Command.Values) == 0 {
+		return fmt.Errorf("'initContainer.command' cannot be empty")
+	}
+	return validateImageReference("initContainer.image", c.Image)
+}
+
+func validateImage(field string, image *BuildInfo) error {
+	if image == nil {
+		return nil
+	}
+	for _, arg := range image.Args {
+		if arg.ValueFrom != nil {
+			return fmt.Errorf("'%s.args' can't read '%s' from a secret or a config map", field, arg.Name)
+		}
+	}
+	return validateImageReference(field, image.Name)
+}
+
+// validateImageReference checks that image follows the grammar of the registry references,
+// e.g. 'okteto/golang:1', 'registry.example.com:5000/team/app@sha256:<digest>'
+func validateImageReference(field, image string) error {
+	if image == "" {
+		return nil
+	}
+	if _, err := reference.ParseNormalizedNamed(image); err != nil {
+		return fmt.Errorf("'%s' is not a valid image reference: '%s' (%s)", field, image, err)
+	}
+	return nil
+}
+
+func validateResources(r ResourceRequirements) error {
+	for name, request := range r.Requests {
+		limit, ok := r.Limits[name]
+		if !ok {
+			continue
+		}
+		if limit.Cmp(request) < 0 {
+			return fmt.Errorf("'resources.limits.%s' (%s) must be greater than or equal to 'resources.requests.%s' (%s)", name, limit.String(), name, request.String())
+		}
+	}
+	return nil
+}
+
 func validateSecrets(secrets []Secret) error {
 	seen := map[string]bool{}
 	for _, s := range secrets {
@@ -670,6 +1196,19 @@ func (dev *Dev) Save(path string) error {
 	return nil
 }
 
+//Hash returns a hash of the loaded manifest, used to detect changes between runs.
+//It's computed over the parsed and defaulted manifest, so comments, formatting and field order don't change it
+func (dev *Dev) Hash() string {
+	b, err := json.Marshal(dev)
+	if err != nil {
+		log.Infof("failed to marshal development container: %s", err)
+		return ""
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
 //SerializeBuildArgs returns build  aaargs as a llist of strings
 func SerializeBuildArgs(buildArgs []EnvVar) []string {
 	result := []string{}
@@ -721,6 +1260,9 @@ func (dev *Dev) ToTranslationRule(main *Dev) *TranslationRule {
 		SecurityContext:  dev.SecurityContext,
 		Resources:        dev.Resources,
 		Healthchecks:     dev.Healthchecks,
+		Probes:           dev.Probes,
+		Lifecycle:        dev.Lifecycle,
+		InitContainer:    dev.InitContainer,
 	}
 
 	if !dev.EmptyImage {
@@ -818,6 +1360,7 @@ func (dev *Dev) ToTranslationRule(main *Dev) *TranslationRule {
 				Name:      v.Name,
 				MountPath: v.MountPath,
 				SubPath:   v.SubPath,
+				ReadOnly:  v.ReadOnly,
 			},
 		)
 	}
@@ -826,10 +1369,17 @@ func (dev *Dev) ToTranslationRule(main *Dev) *TranslationRule {
 }
 
 //LoadContext loads the dev namespace and context
+//The flags take precedence over OKTETO_NAMESPACE and OKTETO_CONTEXT, and both over the values of the manifest
 func (dev *Dev) LoadContext(namespace, k8sContext string) {
+	if namespace == "" {
+		namespace = os.Getenv("OKTETO_NAMESPACE")
+	}
 	if namespace != "" {
 		dev.Namespace = namespace
 	}
+	if k8sContext == "" {
+		k8sContext = os.Getenv("OKTETO_CONTEXT")
+	}
 	if k8sContext != "" {
 		dev.Context = k8sContext
 	}
@@ -910,11 +1460,31 @@ func (s *Secret) GetFileName() string {
 	return filepath.Base(s.RemotePath)
 }
 
-//ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}"
+//ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}".
+//Undefined variables without a default are an error, and "$$" is a literal "$"
 func ExpandEnv(value string) (string, error) {
+	escaped := strings.ReplaceAll(value, "$$", escapedDollar)
+	result, err := envsubst.StringRestricted(escaped, true, false)
+	if err != nil {
+		return "", fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
+	}
+	return strings.ReplaceAll(result, escapedDollar, "$"), nil
+}
+
+// ExpandEnvLenient expands the environment variables in value, undefined variables expand to an empty string.
+// Stack files keep this behavior, okteto manifests use ExpandEnv
+func ExpandEnvLenient(value string) (string, error) {
 	result, err := envsubst.String(value)
 	if err != nil {
 		return "", fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
 	}
 	return result, nil
 }
+
+func expandEnvField(field, value string) (string, error) {
+	result, err := ExpandEnv(value)
+	if err != nil {
+		return "", fmt.Errorf("'%s': %s", field, err.Error())
+	}
+	return result, nil
+}
@@ -19,6 +19,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -71,7 +72,7 @@ services:
         drop:
           - SYS_NICE
     workdir: /app`)
-	main, err := Read(manifest)
+	main, err := Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -135,12 +136,52 @@ requests:
     memory: "128Mi"
     cpu: "500m"
 workdir: /app`)
-	_, err := Read(manifest)
+	_, err := Parse(manifest)
 	if err == nil {
 		t.Errorf("manifest with bad attribute didn't fail to load")
 	}
 }
 
+func Test_unknownField(t *testing.T) {
+	manifest := []byte(`
+name: deployment
+syncs:
+  - .:/app`)
+	_, err := Parse(manifest)
+	if err == nil {
+		t.Fatal("manifest with unknown field didn't fail to load")
+	}
+	if !strings.Contains(err.Error(), "line 3: field syncs not found") {
+		t.Errorf("error doesn't point to the unknown field: %s", err.Error())
+	}
+}
+
+func Test_ReadFromReader(t *testing.T) {
+	manifest := `name: test
+image: okteto/go:1
+sync:
+  - .:/app
+  - missing-folder:/missing`
+
+	dev, err := Read(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dev.Sync.Folders) != 1 {
+		t.Fatalf("expected 1 sync folder, got %d", len(dev.Sync.Folders))
+	}
+
+	if dev.Sync.Folders[0].LocalPath != cwd {
+		t.Errorf("got '%s' expected '%s'", dev.Sync.Folders[0].LocalPath, cwd)
+	}
+}
+
 func Test_LoadDevDefaults(t *testing.T) {
 	var tests = []struct {
 		name                string
@@ -196,7 +237,7 @@ forward:
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			d, err := Read(tt.manifest)
+			d, err := Parse(tt.manifest)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -239,6 +280,7 @@ func Test_loadName(t *testing.T) {
 		value     string
 		onService bool
 		want      string
+		wantErr   bool
 	}{
 		{
 			name:    "no-var",
@@ -256,7 +298,13 @@ func Test_loadName(t *testing.T) {
 			name:    "mising",
 			devName: "code-${valueX}",
 			value:   "1",
-			want:    "code-",
+			wantErr: true,
+		},
+		{
+			name:    "default",
+			devName: "code-${valueX:-2}",
+			value:   "1",
+			want:    "code-2",
 		},
 		{
 			name:      "no-var-vc",
@@ -277,7 +325,7 @@ func Test_loadName(t *testing.T) {
 			devName:   "code-${valueX}",
 			value:     "1",
 			onService: true,
-			want:      "code-",
+			wantErr:   true,
 		},
 	}
 
@@ -294,7 +342,16 @@ services:
 			}
 
 			os.Setenv("value", tt.value)
-			dev, err := Read(manifest)
+			dev, err := Parse(manifest)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for undefined variable")
+				}
+				if !strings.Contains(err.Error(), "'name'") {
+					t.Errorf("error doesn't name the field: %s", err.Error())
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -313,10 +370,11 @@ services:
 
 func Test_loadLabels(t *testing.T) {
 	tests := []struct {
-		name   string
-		labels map[string]string
-		value  string
-		want   map[string]string
+		name    string
+		labels  map[string]string
+		value   string
+		want    map[string]string
+		wantErr bool
 	}{
 		{
 			name:   "no-var",
@@ -331,10 +389,10 @@ func Test_loadLabels(t *testing.T) {
 			want:   map[string]string{"a": "1", "b": "3"},
 		},
 		{
-			name:   "mising",
-			labels: map[string]string{"a": "1", "b": "${valueX}"},
-			value:  "1",
-			want:   map[string]string{"a": "1", "b": ""},
+			name:    "mising",
+			labels:  map[string]string{"a": "1", "b": "${valueX}"},
+			value:   "1",
+			wantErr: true,
 		},
 	}
 
@@ -342,7 +400,16 @@ func Test_loadLabels(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			dev := &Dev{Labels: tt.labels}
 			os.Setenv("value", tt.value)
-			dev.loadLabels()
+			err := dev.loadLabels()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for undefined variable")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
 
 			if !reflect.DeepEqual(tt.want, dev.Labels) {
 				t.Errorf("got: '%v', expected: '%v'", dev.Labels, tt.want)
@@ -358,6 +425,7 @@ func Test_loadImage(t *testing.T) {
 		image     string
 		tagValue  string
 		onService bool
+		wantErr   bool
 	}{
 		{
 			name:     "tag",
@@ -379,9 +447,9 @@ func Test_loadImage(t *testing.T) {
 		},
 		{
 			name:     "missing-tag",
-			want:     "code/core:",
 			image:    "code/core:${image}",
 			tagValue: "tag",
+			wantErr:  true,
 		},
 		{
 			name:      "tag-svc",
@@ -406,10 +474,10 @@ func Test_loadImage(t *testing.T) {
 		},
 		{
 			name:      "missing-tag-svc",
-			want:      "code/core:",
 			image:     "code/core:${image}",
 			tagValue:  "tag",
 			onService: true,
+			wantErr:   true,
 		},
 	}
 
@@ -431,7 +499,16 @@ services:
 			}
 
 			os.Setenv("tag", tt.tagValue)
-			dev, err := Read(manifest)
+			dev, err := Parse(manifest)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for undefined variable")
+				}
+				if !strings.Contains(err.Error(), "'image'") {
+					t.Errorf("error doesn't name the field: %s", err.Error())
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -518,7 +595,7 @@ func Test_LoadRemote(t *testing.T) {
       drop:
       - SYS_NICE
   workdir: /app`)
-	dev, err := Read(manifest)
+	dev, err := Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -550,6 +627,73 @@ func Test_LoadRemote(t *testing.T) {
 	}
 }
 
+func Test_LoadContext(t *testing.T) {
+	var tests = []struct {
+		name              string
+		manifest          string
+		flagNamespace     string
+		flagContext       string
+		envNamespace      string
+		envContext        string
+		expectedNamespace string
+		expectedContext   string
+	}{
+		{
+			name:              "manifest",
+			manifest:          "name: a\nnamespace: staging\ncontext: minikube",
+			expectedNamespace: "staging",
+			expectedContext:   "minikube",
+		},
+		{
+			name:              "env-over-manifest",
+			manifest:          "name: a\nnamespace: staging\ncontext: minikube",
+			envNamespace:      "dev",
+			envContext:        "kind",
+			expectedNamespace: "dev",
+			expectedContext:   "kind",
+		},
+		{
+			name:              "flags-over-env",
+			manifest:          "name: a\nnamespace: staging\ncontext: minikube",
+			flagNamespace:     "prod",
+			flagContext:       "eks",
+			envNamespace:      "dev",
+			envContext:        "kind",
+			expectedNamespace: "prod",
+			expectedContext:   "eks",
+		},
+		{
+			name:              "empty",
+			manifest:          "name: a",
+			expectedNamespace: "",
+			expectedContext:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("OKTETO_NAMESPACE", tt.envNamespace)
+			os.Setenv("OKTETO_CONTEXT", tt.envContext)
+			defer os.Unsetenv("OKTETO_NAMESPACE")
+			defer os.Unsetenv("OKTETO_CONTEXT")
+
+			dev, err := Parse([]byte(tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dev.LoadContext(tt.flagNamespace, tt.flagContext)
+			if dev.Namespace != tt.expectedNamespace {
+				t.Errorf("got namespace '%s', expected '%s'", dev.Namespace, tt.expectedNamespace)
+			}
+
+			if dev.Context != tt.expectedContext {
+				t.Errorf("got context '%s', expected '%s'", dev.Context, tt.expectedContext)
+			}
+		})
+	}
+}
+
 func Test_Reverse(t *testing.T) {
 	manifest := []byte(`
   name: deployment
@@ -561,7 +705,7 @@ func Test_Reverse(t *testing.T) {
     key2: value2
   reverse:
     - 8080:8080`)
-	dev, err := Read(manifest)
+	dev, err := Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -589,7 +733,7 @@ func Test_LoadForcePull(t *testing.T) {
   services:
     - name: b
       imagePullPolicy: IfNotPresent`)
-	dev, err := Read(manifest)
+	dev, err := Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -614,6 +758,150 @@ func Test_LoadForcePull(t *testing.T) {
 	}
 }
 
+func Test_SyncRemotePathDefaultsToWorkdir(t *testing.T) {
+	manifest := []byte(`name: deployment
+workdir: /app
+sync:
+  - .
+  - ../lib:/lib
+services:
+  - name: worker
+    workdir: /src
+    sync:
+      - worker`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []SyncFolder{{LocalPath: ".", RemotePath: "/app"}, {LocalPath: "../lib", RemotePath: "/lib"}}
+	if !reflect.DeepEqual(dev.Sync.Folders, expected) {
+		t.Errorf("got %+v, expected %+v", dev.Sync.Folders, expected)
+	}
+
+	if dev.Services[0].Sync.Folders[0].RemotePath != "/src" {
+		t.Errorf("got %s, expected the workdir of the service", dev.Services[0].Sync.Folders[0].RemotePath)
+	}
+}
+
+func Test_servicesForwards(t *testing.T) {
+	manifest := []byte(`name: deployment
+forward:
+  - 8080:8080
+services:
+  - name: api
+    forward:
+      - 8081:8080
+      - 5432:db:5432`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Forward{
+		{Local: 8081, Remote: 8080, Service: true, ServiceName: "api"},
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+	}
+
+	if got := dev.servicesForwards(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+}
+
+func Test_Hash(t *testing.T) {
+	manifest := []byte(`name: deployment
+image: okteto/golang:1
+command: ["bash"]
+sync:
+  - .:/app
+forward:
+  - 8080:8080`)
+
+	reformatted := []byte(`# comments and field order don't change the hash
+forward:
+- 8080:8080
+sync:
+- .:/app   # the source code
+command:
+    - bash
+image: "okteto/golang:1"
+name: deployment
+`)
+
+	changed := []byte(`name: deployment
+image: okteto/golang:1
+command: ["bash"]
+sync:
+  - .:/app
+forward:
+  - 8080:8081`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	same, err := Parse(reformatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := Parse(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Hash() == "" {
+		t.Fatal("the hash is empty")
+	}
+
+	if dev.Hash() != dev.Hash() {
+		t.Error("the hash is not deterministic")
+	}
+
+	if dev.Hash() != same.Hash() {
+		t.Error("the hash changed when only the format changed")
+	}
+
+	if dev.Hash() == other.Hash() {
+		t.Error("the hash didn't change when the manifest changed")
+	}
+}
+
+func Test_validateImageReference(t *testing.T) {
+	var tests = []struct {
+		image     string
+		expectErr bool
+	}{
+		{image: ""},
+		{image: "golang"},
+		{image: "okteto/golang:1"},
+		{image: "registry.example.com:5000/team/app:1.0-rc.1"},
+		{image: "okteto/golang@sha256:8e8a09f5e9ef7d4c9e4a11fc4e6f4d6a8cd7e12dbbe2f2f4c1ba0a4f3be3e0a1"},
+		{image: "okteto/golang:1@sha256:8e8a09f5e9ef7d4c9e4a11fc4e6f4d6a8cd7e12dbbe2f2f4c1ba0a4f3be3e0a1"},
+		{image: "myimage:latest:foo", expectErr: true},
+		{image: "my image", expectErr: true},
+		{image: "okteto/golang:bad!tag", expectErr: true},
+		{image: "Okteto/golang", expectErr: true},
+		{image: "okteto/golang@sha256:short", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			err := validateImageReference("image", tt.image)
+			if tt.expectErr && err == nil {
+				t.Error("didn't get the expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
+
 func Test_validate(t *testing.T) {
 	file, err := ioutil.TempFile("/tmp", "okteto-secret-test")
 	if err != nil {
@@ -652,6 +940,118 @@ func Test_validate(t *testing.T) {
             - .:/app`),
 			expectErr: false,
 		},
+		{
+			name: "timeout-in-service",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          timeout: 1m
+          sync:
+            - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "environment-secret-key-ref",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: api-credentials
+              key: token`),
+			expectErr: false,
+		},
+		{
+			name: "environment-config-map-key-ref",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: LEVEL
+          valueFrom:
+            configMapKeyRef:
+              name: settings
+              key: log.level`),
+			expectErr: false,
+		},
+		{
+			name: "environment-invalid-ref-name",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: Api_Credentials
+              key: token`),
+			expectErr: true,
+		},
+		{
+			name: "environment-invalid-ref-key",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: api-credentials
+              key: my/token`),
+			expectErr: true,
+		},
+		{
+			name: "environment-both-refs",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: api-credentials
+              key: token
+            configMapKeyRef:
+              name: settings
+              key: token`),
+			expectErr: true,
+		},
+		{
+			name: "environment-empty-value-from",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          valueFrom: {}`),
+			expectErr: true,
+		},
+		{
+			name: "environment-value-and-value-from",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      environment:
+        - name: TOKEN
+          value: abc
+          valueFrom:
+            secretKeyRef:
+              name: api-credentials
+              key: token`),
+			expectErr: true,
+		},
 		{
 			name: "pvc-size",
 			manifest: []byte(`
@@ -749,6 +1149,49 @@ func Test_validate(t *testing.T) {
         - docs:/docs`),
 			expectErr: false,
 		},
+		{
+			name: "external-volumes-invalid-claim-name",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - Dataset_1:/data`),
+			expectErr: true,
+		},
+		{
+			name: "external-volumes-overlap-sync",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - claimName: dataset
+          mountPath: /app/data`),
+			expectErr: true,
+		},
+		{
+			name: "external-volumes-overlap-syncthing",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - dataset:/var/syncthing`),
+			expectErr: true,
+		},
+		{
+			name: "external-volumes-read-only",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - claimName: dataset
+          mountPath: /data
+          readOnly: true`),
+			expectErr: false,
+		},
 		{
 			name: "external-volumes",
 			manifest: []byte(`
@@ -816,38 +1259,585 @@ func Test_validate(t *testing.T) {
       sshServerPort: -1`),
 			expectErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			dev, err := Read(tt.manifest)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			err = dev.validate()
-			if tt.expectErr && err == nil {
-				t.Error("didn't got the expected error")
-			}
-
-			if !tt.expectErr && err != nil {
-				t.Errorf("got an unexpected error: %s", err)
-			}
-
-		})
-	}
-}
-func TestPersistentVolumeEnabled(t *testing.T) {
-	var tests = []struct {
-		name     string
-		manifest []byte
-		expected bool
-	}{
 		{
-			name: "default",
+			name: "valid-init-container",
 			manifest: []byte(`
       name: deployment
-      container: core
+      sync:
+        - .:/app
+      initContainer:
+        image: registry.example.com:5000/tools/migrate:1.0
+        command: make migrate`),
+			expectErr: false,
+		},
+		{
+			name: "init-container-default-image",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        command: ["make", "migrate"]`),
+			expectErr: false,
+		},
+		{
+			name: "init-container-empty-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        image: busybox`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-bad-image",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        image: Bad Image
+        command: make migrate`),
+			expectErr: true,
+		},
+		{
+			name: "resources-limits-above-requests",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      resources:
+        requests:
+          cpu: 500m
+          memory: 1Gi
+        limits:
+          cpu: 1
+          memory: 1Gi`),
+			expectErr: false,
+		},
+		{
+			name: "resources-limits-below-requests",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      resources:
+        requests:
+          memory: 2Gi
+        limits:
+          memory: 1Gi`),
+			expectErr: true,
+		},
+		{
+			name: "labels-and-annotations",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      metadata:
+        labels:
+          app.kubernetes.io/part-of: shop
+      annotations:
+        sidecar.istio.io/inject: "false"`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-label-key",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      labels:
+        part of: shop`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-label-value",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      metadata:
+        labels:
+          team: shop and more`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-annotation-key",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      annotations:
+        /inject: "false"`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-service-label",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          metadata:
+            labels:
+              part of: shop
+          sync:
+            - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "service-name-and-metadata-labels",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          metadata:
+            labels:
+              team: shop
+          sync:
+            - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "relative-workdir",
+			manifest: []byte(`
+      name: deployment
+      workdir: app
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "sync-local-path-with-workdir",
+			manifest: []byte(`
+      name: deployment
+      workdir: /app
+      sync:
+        - .`),
+			expectErr: false,
+		},
+		{
+			name: "sync-local-path-without-workdir",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .`),
+			expectErr: true,
+		},
+		{
+			name: "service-relative-workdir",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          workdir: src
+          sync:
+            - .:/src`),
+			expectErr: true,
+		},
+		{
+			name: "node-selector-and-tolerations",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      nodeSelector:
+        cloud.google.com/gke-nodepool: dev
+      tolerations:
+        - key: dedicated
+          operator: Equal
+          value: dev
+          effect: NoSchedule
+        - operator: Exists`),
+			expectErr: false,
+		},
+		{
+			name: "pull-policy-and-secrets",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullPolicy: IfNotPresent
+      imagePullSecrets:
+        - registry-credentials
+        - mirror.credentials`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-pull-policy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullPolicy: Sometimes`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-pull-secret",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullSecrets:
+        - Registry_Credentials`),
+			expectErr: true,
+		},
+		{
+			name: "duplicated-pull-secret",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullSecrets:
+        - registry-credentials
+        - registry-credentials`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-node-selector",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      nodeSelector:
+        "pool name": dev`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-toleration-operator",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: dedicated
+          operator: In
+          value: dev`),
+			expectErr: true,
+		},
+		{
+			name: "toleration-exists-with-value",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: dedicated
+          operator: Exists
+          value: dev`),
+			expectErr: true,
+		},
+		{
+			name: "toleration-invalid-effect",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: dedicated
+          value: dev
+          effect: Evict`),
+			expectErr: true,
+		},
+		{
+			name: "valid-namespace",
+			manifest: []byte(`
+      name: deployment
+      namespace: staging
+      context: minikube
+      sync:
+        - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-namespace",
+			manifest: []byte(`
+      name: deployment
+      namespace: Staging_1
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "security-context",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        runAsUser: 1000
+        runAsGroup: 1000
+        fsGroup: 1000
+        readOnlyRootFilesystem: true`),
+			expectErr: false,
+		},
+		{
+			name: "security-context-negative-user",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        runAsUser: -1`),
+			expectErr: true,
+		},
+		{
+			name: "security-context-negative-fsgroup",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        fsGroup: -1000`),
+			expectErr: true,
+		},
+		{
+			name: "security-context-capabilities",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        capabilities:
+          add:
+            - SYS_PTRACE
+          drop:
+            - ALL`),
+			expectErr: false,
+		},
+		{
+			name: "security-context-unknown-capability",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        capabilities:
+          add:
+            - SYS_TRACE`),
+			expectErr: true,
+		},
+		{
+			name: "security-context-capability-with-prefix",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        capabilities:
+          drop:
+            - CAP_NET_RAW`),
+			expectErr: true,
+		},
+		{
+			name: "service-security-context-negative-group",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          securityContext:
+            runAsGroup: -1`),
+			expectErr: true,
+		},
+		{
+			name: "service-without-sync-or-forward",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          image: foo:latest`),
+			expectErr: true,
+		},
+		{
+			name: "service-with-forward",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          forward:
+            - 8081:8080`),
+			expectErr: false,
+		},
+		{
+			name: "service-forward-duplicated-local-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      forward:
+        - 8080:8080
+      services:
+        - name: foo
+          forward:
+            - 8080:9090`),
+			expectErr: true,
+		},
+		{
+			name: "service-duplicated-name",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+        - name: foo
+          sync:
+            - .:/src`),
+			expectErr: true,
+		},
+		{
+			name: "service-same-name-as-dev",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: deployment
+          sync:
+            - .:/src`),
+			expectErr: true,
+		},
+		{
+			name: "pvc-storage-class",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      persistentVolume:
+        storageClass: fast-ssd`),
+			expectErr: false,
+		},
+		{
+			name: "pvc-invalid-storage-class",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      persistentVolume:
+        storageClass: Fast_SSD`),
+			expectErr: true,
+		},
+		{
+			name: "pvc-zero-size",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      persistentVolume:
+        size: "0"`),
+			expectErr: true,
+		},
+		{
+			name: "image-with-two-tags",
+			manifest: []byte(`
+      name: deployment
+      image: myimage:latest:foo
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "service-image-with-spaces",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          image: my image
+          sync:
+            - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "command-exec-form",
+			manifest: []byte(`
+      name: deployment
+      command: ["yarn", "dev"]
+      sync:
+        - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "command-empty-first-element",
+			manifest: []byte(`
+      name: deployment
+      command: ["", "dev"]
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "command-empty-list",
+			manifest: []byte(`
+      name: deployment
+      command: []
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "service-command-empty-first-element",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          command: [" ", "dev"]
+          sync:
+            - .:/app`),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Parse(tt.manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = dev.validate()
+			if tt.expectErr && err == nil {
+				t.Error("didn't got the expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+
+		})
+	}
+}
+func TestPersistentVolumeEnabled(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest []byte
+		expected bool
+	}{
+		{
+			name: "default",
+			manifest: []byte(`
+      name: deployment
+      container: core
       image: code/core:0.1.8`),
 			expected: true,
 		},
@@ -871,11 +1861,21 @@ func TestPersistentVolumeEnabled(t *testing.T) {
         enabled: false`),
 			expected: false,
 		},
+		{
+			name: "size-only",
+			manifest: []byte(`
+      name: deployment
+      container: core
+      image: code/core:0.1.8
+      persistentVolume:
+        size: 20Gi`),
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dev, err := Read(tt.manifest)
+			dev, err := Parse(tt.manifest)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -889,6 +1889,7 @@ func TestPersistentVolumeEnabled(t *testing.T) {
 
 func Test_ExpandEnv(t *testing.T) {
 	os.Setenv("BAR", "bar")
+	os.Unsetenv("FOO")
 	tests := []struct {
 		name   string
 		value  string
@@ -909,6 +1910,11 @@ func Test_ExpandEnv(t *testing.T) {
 			value:  "value-${FOO:-foo}-value",
 			result: "value-foo-value",
 		},
+		{
+			name:   "escaped",
+			value:  "value-$${BAR}-value",
+			result: "value-${BAR}-value",
+		},
 	}
 
 	for _, tt := range tests {
@@ -923,3 +1929,19 @@ func Test_ExpandEnv(t *testing.T) {
 		})
 	}
 }
+
+func Test_ExpandEnvUndefined(t *testing.T) {
+	os.Unsetenv("UNDEFINED")
+	if _, err := ExpandEnv("value-${UNDEFINED}"); err == nil {
+		t.Fatal("undefined variable didn't fail to expand")
+	}
+
+	result, err := ExpandEnvLenient("value-${UNDEFINED}")
+	if err != nil {
+		t.Fatalf("undefined variable failed to expand in lenient mode: %s", err)
+	}
+
+	if result != "value-" {
+		t.Errorf("got '%s', expected 'value-'", result)
+	}
+}
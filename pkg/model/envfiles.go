@@ -0,0 +1,103 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/subosito/gotenv"
+)
+
+// optionalEnvFileSuffix marks an env file that is ignored when it's missing
+const optionalEnvFileSuffix = "?"
+
+// loadEnvFiles adds the variables of the env files to the environment of the development container and its services.
+// Variables defined in 'environment' take precedence, and later env files take precedence over earlier ones
+func (dev *Dev) loadEnvFiles(devDir string) error {
+	if err := dev.loadEnvFilesVars(devDir); err != nil {
+		return err
+	}
+
+	for _, s := range dev.Services {
+		if err := s.loadEnvFilesVars(devDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dev *Dev) loadEnvFilesVars(devDir string) error {
+	vars := map[string]string{}
+	for _, f := range dev.EnvFiles {
+		env, err := readEnvFile(devDir, f)
+		if err != nil {
+			return err
+		}
+
+		for name, value := range env {
+			vars[name] = value
+		}
+	}
+
+	for _, e := range dev.Environment {
+		delete(vars, e.Name)
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dev.Environment = append(dev.Environment, EnvVar{Name: name, Value: vars[name]})
+	}
+
+	return nil
+}
+
+// readEnvFile parses the KEY=VALUE lines of an env file. Relative paths are resolved from devDir
+func readEnvFile(devDir, path string) (gotenv.Env, error) {
+	optional := strings.HasSuffix(path, optionalEnvFileSuffix)
+	path, err := expandLocalPath("envFiles", strings.TrimSuffix(path, optionalEnvFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	path = loadAbsPath(devDir, path)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if optional {
+				log.Infof("optional env file '%s' not found, ignoring it", path)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("envFiles: '%s' not found. Add '%s' to the end of the path to make it optional", path, optionalEnvFileSuffix)
+		}
+		return nil, fmt.Errorf("failed to read env file '%s': %w", path, err)
+	}
+	defer f.Close()
+
+	env, err := gotenv.StrictParse(f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing env file '%s': %s", path, err.Error())
+	}
+
+	return env, nil
+}
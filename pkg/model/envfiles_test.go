@@ -0,0 +1,103 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadEnvFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_TEST_ENV_FILE_USER", "cindy")
+	defer os.Unsetenv("OKTETO_TEST_ENV_FILE_USER")
+
+	env := []byte(`# database settings
+DB_HOST=localhost
+DB_USER="${OKTETO_TEST_ENV_FILE_USER}"
+DB_PASSWORD='s3cr3t'
+PORT=8080
+`)
+	if err := ioutil.WriteFile(filepath.Join(dir, ".env"), env, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "local.env"), []byte("DB_HOST=db\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := []byte(`name: api
+image: okteto/golang:1
+envFiles:
+  - .env
+  - local.env
+  - missing.env?
+environment:
+  - PORT=3000
+sync:
+  - .:/app`)
+
+	p := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(p, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := Get(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []EnvVar{
+		{Name: "PORT", Value: "3000"},
+		{Name: "DB_HOST", Value: "db"},
+		{Name: "DB_PASSWORD", Value: "s3cr3t"},
+		{Name: "DB_USER", Value: "cindy"},
+	}
+
+	if !reflect.DeepEqual(dev.Environment, expected) {
+		t.Errorf("got %+v, expected %+v", dev.Environment, expected)
+	}
+}
+
+func TestLoadEnvFilesMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := []byte(`name: api
+image: okteto/golang:1
+envFiles:
+  - missing.env
+sync:
+  - .:/app`)
+
+	p := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(p, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Get(p); err == nil {
+		t.Fatal("expected an error for a missing env file")
+	}
+}
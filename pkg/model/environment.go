@@ -0,0 +1,75 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// EnvVarSource is the source of the value of an environment variable, so values stored in the cluster don't have to be in the manifest
+type EnvVarSource struct {
+	SecretKeyRef    *KeySelector `json:"secretKeyRef,omitempty" yaml:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty" yaml:"configMapKeyRef,omitempty"`
+}
+
+// KeySelector selects a key of a secret or a config map
+type KeySelector struct {
+	Name string `json:"name" yaml:"name"`
+	Key  string `json:"key" yaml:"key"`
+}
+
+func validateEnvironment(env []EnvVar) error {
+	for _, e := range env {
+		if e.ValueFrom == nil {
+			continue
+		}
+
+		if e.Value != "" {
+			return fmt.Errorf("environment variable '%s' can't define 'value' and 'valueFrom' at the same time", e.Name)
+		}
+
+		s := e.ValueFrom
+		switch {
+		case s.SecretKeyRef != nil && s.ConfigMapKeyRef != nil:
+			return fmt.Errorf("environment variable '%s' can't define 'secretKeyRef' and 'configMapKeyRef' at the same time", e.Name)
+		case s.SecretKeyRef != nil:
+			if err := validateKeySelector(e.Name, "secretKeyRef", s.SecretKeyRef); err != nil {
+				return err
+			}
+		case s.ConfigMapKeyRef != nil:
+			if err := validateKeySelector(e.Name, "configMapKeyRef", s.ConfigMapKeyRef); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("environment variable '%s' must define 'secretKeyRef' or 'configMapKeyRef' in 'valueFrom'", e.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateKeySelector(name, field string, s *KeySelector) error {
+	if errs := validation.IsDNS1123Subdomain(s.Name); len(errs) > 0 {
+		return fmt.Errorf("environment variable '%s': '%s.name' is not valid: %s", name, field, strings.Join(errs, ", "))
+	}
+
+	if errs := validation.IsConfigMapKey(s.Key); len(errs) > 0 {
+		return fmt.Errorf("environment variable '%s': '%s.key' is not valid: %s", name, field, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
@@ -19,7 +19,12 @@ import (
 	"strings"
 )
 
-const malformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+const (
+	malformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+	invalidPortForward   = "Invalid port-forward '%s': ports must be between 1 and 65535"
+
+	maxPort = 65535
+)
 
 // Forward represents a port forwarding definition
 type Forward struct {
@@ -59,7 +64,7 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 
 		f.Remote = p
-		return nil
+		return f.validateRange(raw)
 	}
 
 	f.Service = true
@@ -70,6 +75,49 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	f.Remote = p
+	return f.validateRange(raw)
+}
+
+func (f *Forward) validateRange(raw string) error {
+	if !isValidPort(f.Local) || !isValidPort(f.Remote) {
+		return fmt.Errorf(invalidPortForward, raw)
+	}
+	return nil
+}
+
+func isValidPort(port int) bool {
+	return port > 0 && port <= maxPort
+}
+
+func validateForwards(forwards []Forward) error {
+	seen := map[int]bool{}
+	for _, f := range forwards {
+		if seen[f.Local] {
+			return fmt.Errorf("Invalid port-forward '%s': local port %d is already used by another port-forward", f.String(), f.Local)
+		}
+		seen[f.Local] = true
+	}
+	return nil
+}
+
+func validateReverses(reverses []Reverse, forwards []Forward) error {
+	forwarded := map[int]bool{}
+	for _, f := range forwards {
+		forwarded[f.Local] = true
+	}
+
+	seen := map[int]bool{}
+	for _, r := range reverses {
+		raw := fmt.Sprintf("%d:%d", r.Remote, r.Local)
+		if seen[r.Remote] {
+			return fmt.Errorf("Invalid reverse '%s': remote port %d is already used by another reverse", raw, r.Remote)
+		}
+		seen[r.Remote] = true
+
+		if forwarded[r.Local] {
+			return fmt.Errorf("Invalid reverse '%s': local port %d is already used by a port-forward", raw, r.Local)
+		}
+	}
 	return nil
 }
 
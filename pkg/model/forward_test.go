@@ -108,6 +108,21 @@ func TestForward_UnmarshalYAML(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:      "local-port-zero",
+			data:      "0:8080",
+			expectErr: true,
+		},
+		{
+			name:      "remote-port-too-big",
+			data:      "8080:65536",
+			expectErr: true,
+		},
+		{
+			name:      "service-port-negative",
+			data:      "8080:svc:-1",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -190,3 +205,77 @@ func TestForward_less(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateForwards(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwards  []Forward
+		expectErr bool
+	}{
+		{
+			name:     "ok",
+			forwards: []Forward{{Local: 8080, Remote: 80}, {Local: 5432, Remote: 5432, Service: true, ServiceName: "db"}},
+		},
+		{
+			name:      "duplicated-local",
+			forwards:  []Forward{{Local: 8080, Remote: 80}, {Local: 8080, Remote: 8080, Service: true, ServiceName: "api"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateForwards(tt.forwards)
+			if tt.expectErr && err == nil {
+				t.Fatal("didn't got expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.expectErr && !strings.Contains(err.Error(), "8080:api:8080") {
+				t.Errorf("error doesn't name the port-forward: %s", err.Error())
+			}
+		})
+	}
+}
+
+func Test_validateReverses(t *testing.T) {
+	tests := []struct {
+		name      string
+		reverses  []Reverse
+		forwards  []Forward
+		expectErr bool
+	}{
+		{
+			name:     "ok",
+			reverses: []Reverse{{Remote: 9000, Local: 9000}, {Remote: 9001, Local: 9001}},
+			forwards: []Forward{{Local: 8080, Remote: 8080}},
+		},
+		{
+			name:      "duplicated-remote",
+			reverses:  []Reverse{{Remote: 9000, Local: 9000}, {Remote: 9000, Local: 9001}},
+			expectErr: true,
+		},
+		{
+			name:      "collides-with-forward",
+			reverses:  []Reverse{{Remote: 9000, Local: 8080}},
+			forwards:  []Forward{{Local: 8080, Remote: 8080}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReverses(tt.reverses, tt.forwards)
+			if tt.expectErr && err == nil {
+				t.Fatal("didn't got expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
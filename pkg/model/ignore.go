@@ -0,0 +1,194 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// StignoreHeader is the first line of the '.stignore' files generated from 'sync.ignore'
+const StignoreHeader = "// Generated by okteto from the 'sync' section of your okteto manifest, local changes will be overwritten"
+
+// HasIgnores returns true if the manifest defines the ignored patterns of the synchronized folders
+func (s *Sync) HasIgnores() bool {
+	return len(s.Ignore) > 0 || s.Gitignore
+}
+
+// Stignore returns the content of the '.stignore' file of folder: the patterns of its '.gitignore' file
+// when 'sync.gitignore' is enabled, followed by the patterns of 'sync.ignore'.
+// The '.git' folder is ignored too, unless the patterns negate it
+func (s *Sync) Stignore(folder string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, StignoreHeader)
+
+	negatesGit := false
+	if s.Gitignore {
+		patterns, err := readGitignore(filepath.Join(folder, ".gitignore"))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(patterns) > 0 {
+			fmt.Fprintln(&buf, "// .gitignore")
+			for _, p := range patterns {
+				negatesGit = negatesGit || isGitNegation(p)
+				fmt.Fprintln(&buf, p)
+			}
+		}
+	}
+
+	if len(s.Ignore) > 0 {
+		fmt.Fprintln(&buf, "// sync.ignore")
+		for _, p := range s.Ignore {
+			p = stignorePattern(p)
+			negatesGit = negatesGit || isGitNegation(p)
+			fmt.Fprintln(&buf, p)
+		}
+	}
+
+	if !negatesGit {
+		fmt.Fprintln(&buf, "// okteto defaults")
+		fmt.Fprintln(&buf, ".git")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// isGitNegation returns true if p is a negation of the '.git' folder, like '!.git' or '!/.git/'
+func isGitNegation(p string) bool {
+	pattern, negated := trimIgnorePrefixes(p)
+	return negated && strings.Trim(pattern, "/") == ".git"
+}
+
+// IsGeneratedStignore returns true if content was generated by Stignore
+func IsGeneratedStignore(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(StignoreHeader))
+}
+
+// readGitignore returns the patterns of a '.gitignore' file in the syntax of syncthing.
+// A missing file has no patterns
+func readGitignore(p string) ([]string, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read '%s': %s", p, err)
+	}
+
+	patterns := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		if p := gitignorePattern(scanner.Text()); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+
+	return patterns, scanner.Err()
+}
+
+// gitignorePattern translates a line of a '.gitignore' file to a syncthing pattern.
+// Folder patterns like 'build/' lose their trailing slash, syncthing ignores the content of ignored folders
+func gitignorePattern(line string) string {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(line, "#") {
+		return "//" + strings.TrimPrefix(line, "#")
+	}
+
+	if strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+
+	if trimmed := strings.TrimRight(line, "/"); trimmed != "" && trimmed != "!" {
+		line = trimmed
+	}
+
+	return line
+}
+
+// stignorePattern translates the '#' comments of 'sync.ignore' to the '//' comments of syncthing
+func stignorePattern(p string) string {
+	p = strings.TrimSpace(p)
+	if isIgnoreComment(p) && strings.HasPrefix(p, "#") {
+		return "//" + strings.TrimPrefix(p, "#")
+	}
+
+	return p
+}
+
+func isIgnoreComment(p string) bool {
+	if strings.HasPrefix(p, "//") {
+		return true
+	}
+
+	return strings.HasPrefix(p, "#") && !strings.HasPrefix(p, "#include")
+}
+
+func validateSyncIgnore(patterns []string) error {
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return fmt.Errorf("'sync.ignore' cannot contain empty patterns")
+		}
+
+		if isIgnoreComment(p) {
+			continue
+		}
+
+		if strings.HasPrefix(p, "#include") {
+			if strings.TrimSpace(strings.TrimPrefix(p, "#include")) == "" {
+				return fmt.Errorf("'sync.ignore' contains an '#include' without a file")
+			}
+			continue
+		}
+
+		pattern, _ := trimIgnorePrefixes(p)
+		if pattern == "" || pattern == "/" {
+			return fmt.Errorf("'sync.ignore' contains an invalid pattern '%s': the pattern is empty", p)
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("'sync.ignore' contains an invalid pattern '%s': %s", p, err)
+		}
+	}
+
+	return nil
+}
+
+// trimIgnorePrefixes removes the '!', '(?d)' and '(?i)' prefixes of a syncthing pattern, and returns if it's a negation
+func trimIgnorePrefixes(p string) (string, bool) {
+	negated := false
+	for {
+		trimmed := strings.TrimPrefix(p, "!")
+		negated = negated || trimmed != p
+		trimmed = strings.TrimPrefix(trimmed, "(?d)")
+		trimmed = strings.TrimPrefix(trimmed, "(?i)")
+		if trimmed == p {
+			return p, negated
+		}
+		p = trimmed
+	}
+}
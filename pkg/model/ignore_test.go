@@ -0,0 +1,183 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func Test_syncIgnoreUnmarshalling(t *testing.T) {
+	manifest := []byte(`sync:
+  folders:
+    - .:/app
+  gitignore: true
+  ignore:
+    - node_modules
+    - "# build artifacts"
+    - /build
+    - "!build/keep.txt"`)
+
+	var s struct {
+		Sync Sync `yaml:"sync"`
+	}
+	if err := yaml.Unmarshal(manifest, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"node_modules", "# build artifacts", "/build", "!build/keep.txt"}
+	if !reflect.DeepEqual(s.Sync.Ignore, expected) {
+		t.Errorf("expected %v, got %v", expected, s.Sync.Ignore)
+	}
+
+	if !s.Sync.Gitignore {
+		t.Error("gitignore wasn't parsed")
+	}
+
+	if s.Sync.RescanInterval != DefaultSyncthingRescanInterval {
+		t.Errorf("expected the default rescan interval, got %d", s.Sync.RescanInterval)
+	}
+
+	out, err := yaml.Marshal(s.Sync)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(out), "ignore:") || !strings.Contains(string(out), "gitignore: true") {
+		t.Errorf("the ignores weren't marshalled: %s", out)
+	}
+}
+
+func Test_validateSyncIgnore(t *testing.T) {
+	var tests = []struct {
+		name      string
+		patterns  []string
+		expectErr bool
+	}{
+		{name: "none", patterns: nil},
+		{name: "valid", patterns: []string{"node_modules", "/build", "**/*.log", "(?d).DS_Store", "(?i)*.TMP"}},
+		{name: "negation", patterns: []string{"build", "!build/keep.txt"}},
+		{name: "comments", patterns: []string{"// dependencies", "# artifacts"}},
+		{name: "include", patterns: []string{"#include .stglobalignore"}},
+		{name: "include-without-file", patterns: []string{"#include"}, expectErr: true},
+		{name: "empty", patterns: []string{"  "}, expectErr: true},
+		{name: "only-negation", patterns: []string{"!"}, expectErr: true},
+		{name: "only-prefixes", patterns: []string{"!(?d)"}, expectErr: true},
+		{name: "invalid-pattern", patterns: []string{"[build"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSyncIgnore(tt.patterns)
+			if tt.expectErr && err == nil {
+				t.Error("expected error but got none")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func Test_gitignorePattern(t *testing.T) {
+	var tests = []struct {
+		line     string
+		expected string
+	}{
+		{line: "", expected: ""},
+		{line: "   ", expected: ""},
+		{line: "# comment", expected: "// comment"},
+		{line: "node_modules/", expected: "node_modules"},
+		{line: "/dist/  ", expected: "/dist"},
+		{line: "!dist/keep/", expected: "!dist/keep"},
+		{line: "\\#file", expected: "#file"},
+		{line: "*.log\r", expected: "*.log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := gitignorePattern(tt.line); got != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSync_Stignore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &Sync{Ignore: []string{"node_modules", "# artifacts", "!build/keep.txt"}}
+	got, err := s.Stignore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := StignoreHeader + "\n// sync.ignore\nnode_modules\n// artifacts\n!build/keep.txt\n// okteto defaults\n.git\n"
+	if string(got) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if !IsGeneratedStignore(got) {
+		t.Error("the content wasn't detected as generated")
+	}
+
+	s.Gitignore = true
+	got, err = s.Stignore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != expected {
+		t.Errorf("a missing .gitignore changed the content:\n%s", got)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, ".gitignore"), []byte("# deps\nvendor/\n\n*.log\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = s.Stignore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = StignoreHeader + "\n// .gitignore\n// deps\nvendor\n*.log\n// sync.ignore\nnode_modules\n// artifacts\n!build/keep.txt\n// okteto defaults\n.git\n"
+	if string(got) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	s = &Sync{Ignore: []string{"(?d)!/.git/", "node_modules"}}
+	got, err = s.Stignore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(got), "\n.git\n") {
+		t.Errorf("'.git' was ignored even if it's negated:\n%s", got)
+	}
+
+	if IsGeneratedStignore([]byte(".git\nnode_modules\n")) {
+		t.Error("a user file was detected as generated")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	includeField = "include"
+
+	// maxIncludeDepth is the maximum number of nested includes
+	maxIncludeDepth = 10
+)
+
+// loadIncludes merges the manifests listed in the include field of b underneath it, so large manifests can be split in several files.
+// Included manifests can include other manifests, their paths are relative to the folder of the including file.
+// Fields of the including manifest override the included ones, and later includes override earlier ones.
+// manifestPath is the path of b, if it was read from a file
+func loadIncludes(b []byte, devDir, manifestPath string) ([]byte, error) {
+	manifest := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		// Parse reports a better error
+		return b, nil
+	}
+
+	if _, ok := manifest[includeField]; !ok {
+		return b, nil
+	}
+
+	visiting := map[string]bool{}
+	if manifestPath != "" {
+		visiting[filepath.Clean(manifestPath)] = true
+	}
+
+	merged, err := resolveIncludes(manifest, devDir, visiting, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// resolveIncludes returns manifest merged over its includes. visiting holds the files being included, to detect cycles
+func resolveIncludes(manifest map[interface{}]interface{}, dir string, visiting map[string]bool, depth int) (map[interface{}]interface{}, error) {
+	paths, err := getIncludes(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(manifest, includeField)
+	if len(paths) == 0 {
+		return manifest, nil
+	}
+
+	if depth >= maxIncludeDepth {
+		return nil, fmt.Errorf("manifest includes are nested more than %d levels", maxIncludeDepth)
+	}
+
+	fromIncludes := []string{}
+	result := map[interface{}]interface{}{}
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		p = filepath.Clean(p)
+
+		if visiting[p] {
+			return nil, fmt.Errorf("include cycle detected: '%s' includes itself", p)
+		}
+
+		included, err := readInclude(p)
+		if err != nil {
+			return nil, err
+		}
+
+		visiting[p] = true
+		included, err = resolveIncludes(included, filepath.Dir(p), visiting, depth+1)
+		delete(visiting, p)
+		if err != nil {
+			return nil, err
+		}
+
+		result = mergeManifests(result, included, "", &fromIncludes)
+	}
+
+	result = mergeManifests(result, manifest, "", &fromIncludes)
+	if len(fromIncludes) > 0 {
+		sort.Strings(fromIncludes)
+		log.Debugf("fields loaded from the includes of '%s': %s", dir, strings.Join(fromIncludes, ", "))
+	}
+
+	return result, nil
+}
+
+func getIncludes(manifest map[interface{}]interface{}) ([]string, error) {
+	v, ok := manifest[includeField]
+	if !ok || v == nil {
+		return nil, nil
+	}
+
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' must be a list of paths", includeField)
+	}
+
+	paths := make([]string, 0, len(list))
+	for _, item := range list {
+		p, ok := item.(string)
+		if !ok || p == "" {
+			return nil, fmt.Errorf("'%s' must be a list of paths", includeField)
+		}
+		paths = append(paths, p)
+	}
+
+	return paths, nil
+}
+
+func readInclude(path string) (map[interface{}]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the included manifest '%s': %w", path, err)
+	}
+
+	included := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &included); err != nil {
+		return nil, fmt.Errorf("the included manifest '%s' is not valid: %w", path, err)
+	}
+
+	if _, err := getConfigVersion(included); err != nil {
+		return nil, fmt.Errorf("the included manifest '%s' is not valid: %w", path, err)
+	}
+	// the version of an included manifest doesn't apply to the including one
+	delete(included, manifestVersionField)
+
+	rebaseLocalPaths(included, filepath.Dir(path))
+	return included, nil
+}
+
+// rebaseLocalPaths makes the relative local paths of an included manifest absolute.
+// They are relative to the folder of the included file, and the merged manifest resolves them from the folder of the root manifest
+func rebaseLocalPaths(m map[interface{}]interface{}, dir string) {
+	switch sync := m["sync"].(type) {
+	case []interface{}:
+		rebasePrefixPaths(sync, dir)
+	case map[interface{}]interface{}:
+		if folders, ok := sync["folders"].([]interface{}); ok {
+			rebasePrefixPaths(folders, dir)
+		}
+	}
+
+	if envFiles, ok := m["envFiles"].([]interface{}); ok {
+		for i := range envFiles {
+			if p, ok := envFiles[i].(string); ok {
+				envFiles[i] = rebaseLocalPath(p, dir)
+			}
+		}
+	}
+
+	if secrets, ok := m["secrets"].([]interface{}); ok {
+		rebasePrefixPaths(secrets, dir)
+		for _, secret := range secrets {
+			rebaseMapPaths(secret, dir, "localPath")
+		}
+	}
+
+	for _, field := range []string{"image", "build", "push"} {
+		rebaseMapPaths(m[field], dir, "context", "dockerfile")
+	}
+
+	if services, ok := m["services"].([]interface{}); ok {
+		for _, s := range services {
+			if service, ok := s.(map[interface{}]interface{}); ok {
+				rebaseLocalPaths(service, dir)
+			}
+		}
+	}
+}
+
+// rebasePrefixPaths rebases the local path of the elements of list with the syntax 'localPath:remotePath'
+func rebasePrefixPaths(list []interface{}, dir string) {
+	for i := range list {
+		v, ok := list[i].(string)
+		if !ok {
+			continue
+		}
+
+		parts := strings.SplitN(v, ":", 2)
+		parts[0] = rebaseLocalPath(parts[0], dir)
+		list[i] = strings.Join(parts, ":")
+	}
+}
+
+func rebaseMapPaths(v interface{}, dir string, keys ...string) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return
+	}
+
+	for _, k := range keys {
+		if p, ok := m[k].(string); ok {
+			m[k] = rebaseLocalPath(p, dir)
+		}
+	}
+}
+
+// rebaseLocalPath returns p relative to dir. Paths from the home folder or from environment variables are expanded later, they aren't modified
+func rebaseLocalPath(p, dir string) string {
+	if p == "" || filepath.IsAbs(p) || strings.HasPrefix(p, "~") || strings.HasPrefix(p, "$") {
+		return p
+	}
+
+	return filepath.Join(dir, p)
+}
@@ -0,0 +1,288 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func Test_loadIncludes(t *testing.T) {
+	var tests = []struct {
+		name      string
+		files     map[string]string
+		manifest  string
+		expected  map[interface{}]interface{}
+		expectErr bool
+	}{
+		{
+			name:     "no-includes",
+			manifest: "name: api\nimage: okteto/golang:1\n",
+			expected: map[interface{}]interface{}{"name": "api", "image": "okteto/golang:1"},
+		},
+		{
+			name: "manifest-overrides-includes",
+			files: map[string]string{
+				"base.yml":  "image: okteto/golang:1\nworkdir: /base\n",
+				"extra.yml": "workdir: /extra\nmountpath: /src\n",
+			},
+			manifest: "include:\n  - base.yml\n  - extra.yml\nname: api\nmountpath: /app\n",
+			expected: map[interface{}]interface{}{
+				"name":      "api",
+				"image":     "okteto/golang:1",
+				"workdir":   "/extra",
+				"mountpath": "/app",
+			},
+		},
+		{
+			name: "nested-relative-to-including-file",
+			files: map[string]string{
+				"shared/base.yml":      "include:\n  - resources.yml\nimage: okteto/golang:1\n",
+				"shared/resources.yml": "resources:\n  limits:\n    cpu: 1\n",
+			},
+			manifest: "include:\n  - shared/base.yml\nname: api\n",
+			expected: map[interface{}]interface{}{
+				"name":  "api",
+				"image": "okteto/golang:1",
+				"resources": map[interface{}]interface{}{
+					"limits": map[interface{}]interface{}{"cpu": 1},
+				},
+			},
+		},
+		{
+			name: "same-file-included-twice",
+			files: map[string]string{
+				"a.yml":    "include:\n  - base.yml\nworkdir: /a\n",
+				"b.yml":    "include:\n  - base.yml\n",
+				"base.yml": "image: okteto/golang:1\n",
+			},
+			manifest: "include:\n  - a.yml\n  - b.yml\nname: api\n",
+			expected: map[interface{}]interface{}{
+				"name":    "api",
+				"image":   "okteto/golang:1",
+				"workdir": "/a",
+			},
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"a.yml": "include:\n  - b.yml\n",
+				"b.yml": "include:\n  - a.yml\n",
+			},
+			manifest:  "include:\n  - a.yml\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name: "cycle-with-root",
+			files: map[string]string{
+				"a.yml": "include:\n  - okteto.yml\n",
+			},
+			manifest:  "include:\n  - a.yml\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name: "self-include",
+			files: map[string]string{
+				"a.yml": "include:\n  - ./a.yml\n",
+			},
+			manifest:  "include:\n  - a.yml\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name:      "missing-file",
+			manifest:  "include:\n  - missing.yml\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name:      "not-a-list",
+			manifest:  "include: base.yml\nname: api\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", t.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			for name, content := range tt.files {
+				p := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			b, err := loadIncludes([]byte(tt.manifest), dir, filepath.Join(dir, "okteto.yml"))
+			if err != nil {
+				if !tt.expectErr {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if tt.expectErr {
+				t.Fatalf("expected error, got %s", string(b))
+			}
+
+			got := map[interface{}]interface{}{}
+			if err := yaml.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_loadIncludesMaxDepth(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// every file includes a different one, so there is no cycle
+	for i := 0; i <= maxIncludeDepth; i++ {
+		content := []byte("include:\n  - next/okteto.yml\n")
+		p := filepath.Join(dir, strings.Repeat("next/", i), "okteto.yml")
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := loadIncludes([]byte("include:\n  - okteto.yml\nname: api\n"), dir, ""); err == nil {
+		t.Fatal("expected an error when the includes are nested too deep")
+	}
+}
+
+func Test_loadIncludesLocalPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	shared := filepath.Join(dir, "shared")
+	if err := os.MkdirAll(shared, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `sync:
+  - src:/app
+  - /abs:/abs
+envFiles:
+  - .env
+secrets:
+  - secret.txt:/etc/secret.txt
+  - localPath: $HOME/key
+    remotePath: /etc/key
+build:
+  context: api
+services:
+  - name: worker
+    sync:
+      - ~/worker:/src
+`
+	if err := ioutil.WriteFile(filepath.Join(shared, "base.yml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := loadIncludes([]byte("include:\n  - shared/base.yml\nname: api\n"), dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[interface{}]interface{}{
+		"name":     "api",
+		"sync":     []interface{}{filepath.Join(shared, "src") + ":/app", "/abs:/abs"},
+		"envFiles": []interface{}{filepath.Join(shared, ".env")},
+		"secrets": []interface{}{
+			filepath.Join(shared, "secret.txt") + ":/etc/secret.txt",
+			map[interface{}]interface{}{"localPath": "$HOME/key", "remotePath": "/etc/key"},
+		},
+		"build": map[interface{}]interface{}{"context": filepath.Join(shared, "api")},
+		"services": []interface{}{
+			map[interface{}]interface{}{
+				"name": "worker",
+				"sync": []interface{}{"~/worker:/src"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+}
+
+func TestGetServiceWithIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "shared.yml"), []byte(`image: okteto/golang:1
+persistentVolume:
+  size: 10Gi
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte(`include:
+  - shared.yml
+name: api
+persistentVolume:
+  size: 20Gi
+sync:
+  - .:/app
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := GetService(manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Image.Name != "okteto/golang:1" {
+		t.Errorf("the image wasn't loaded from the include: %s", dev.Image.Name)
+	}
+
+	if dev.PersistentVolumeSize() != "20Gi" {
+		t.Errorf("the size of the manifest didn't win: %s", dev.PersistentVolumeSize())
+	}
+}
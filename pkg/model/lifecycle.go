@@ -0,0 +1,76 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lifecycle represents the hooks run by kubernetes when the development container starts and stops
+type Lifecycle struct {
+	PostStart *LifecycleHandler `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PreStop   *LifecycleHandler `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+}
+
+// LifecycleHandler represents the action run by a lifecycle hook
+type LifecycleHandler struct {
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+	Exec    *ExecProbe    `json:"exec,omitempty" yaml:"exec,omitempty"`
+}
+
+func validateLifecycle(l *Lifecycle) error {
+	if l == nil {
+		return nil
+	}
+
+	if err := validateLifecycleHandler("postStart", l.PostStart); err != nil {
+		return err
+	}
+
+	return validateLifecycleHandler("preStop", l.PreStop)
+}
+
+func validateLifecycleHandler(name string, h *LifecycleHandler) error {
+	if h == nil {
+		return nil
+	}
+
+	handlers := 0
+	if h.HTTPGet != nil {
+		handlers++
+		if !isValidPort(h.HTTPGet.Port) {
+			return fmt.Errorf("'lifecycle.%s.httpGet.port' must be between 1 and %d", name, maxPort)
+		}
+
+		switch strings.ToUpper(h.HTTPGet.Scheme) {
+		case "", "HTTP", "HTTPS":
+		default:
+			return fmt.Errorf("supported values for 'lifecycle.%s.httpGet.scheme' are: 'HTTP' or 'HTTPS'", name)
+		}
+	}
+
+	if h.Exec != nil {
+		handlers++
+		if len(h.Exec.Command.Values) == 0 {
+			return fmt.Errorf("'lifecycle.%s.exec.command' cannot be empty", name)
+		}
+	}
+
+	if handlers != 1 {
+		return fmt.Errorf("'lifecycle.%s' must define one of 'httpGet' or 'exec'", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_lifecycleUnmarshalling(t *testing.T) {
+	manifest := []byte(`name: web
+lifecycle:
+  postStart:
+    exec:
+      command: ["sh", "-c", "register.sh"]
+  preStop:
+    httpGet:
+      path: /deregister
+      port: 8080`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Lifecycle{
+		PostStart: &LifecycleHandler{
+			Exec: &ExecProbe{Command: Command{Values: []string{"sh", "-c", "register.sh"}}},
+		},
+		PreStop: &LifecycleHandler{
+			HTTPGet: &HTTPGetProbe{Path: "/deregister", Port: 8080},
+		},
+	}
+
+	if !reflect.DeepEqual(dev.Lifecycle, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dev.Lifecycle)
+	}
+}
+
+func Test_validateLifecycle(t *testing.T) {
+	var tests = []struct {
+		name      string
+		lifecycle *Lifecycle
+		expectErr bool
+	}{
+		{
+			name:      "nil",
+			lifecycle: nil,
+			expectErr: false,
+		},
+		{
+			name: "valid",
+			lifecycle: &Lifecycle{
+				PostStart: &LifecycleHandler{Exec: &ExecProbe{Command: Command{Values: []string{"register.sh"}}}},
+				PreStop:   &LifecycleHandler{HTTPGet: &HTTPGetProbe{Path: "/deregister", Port: 8080, Scheme: "http"}},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "only-pre-stop",
+			lifecycle: &Lifecycle{PreStop: &LifecycleHandler{Exec: &ExecProbe{Command: Command{Values: []string{"deregister.sh"}}}}},
+			expectErr: false,
+		},
+		{
+			name:      "no-handler",
+			lifecycle: &Lifecycle{PostStart: &LifecycleHandler{}},
+			expectErr: true,
+		},
+		{
+			name: "several-handlers",
+			lifecycle: &Lifecycle{
+				PreStop: &LifecycleHandler{
+					HTTPGet: &HTTPGetProbe{Port: 8080},
+					Exec:    &ExecProbe{Command: Command{Values: []string{"deregister.sh"}}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "wrong-port",
+			lifecycle: &Lifecycle{PostStart: &LifecycleHandler{HTTPGet: &HTTPGetProbe{Port: 0}}},
+			expectErr: true,
+		},
+		{
+			name:      "wrong-scheme",
+			lifecycle: &Lifecycle{PreStop: &LifecycleHandler{HTTPGet: &HTTPGetProbe{Port: 8080, Scheme: "ftp"}}},
+			expectErr: true,
+		},
+		{
+			name:      "empty-command",
+			lifecycle: &Lifecycle{PostStart: &LifecycleHandler{Exec: &ExecProbe{}}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLifecycle(tt.lifecycle)
+			if tt.expectErr && err == nil {
+				t.Error("didn't got the expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
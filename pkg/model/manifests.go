@@ -0,0 +1,128 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const devManifestsField = "dev"
+
+// devManifests is the manifest format that defines several development containers
+type devManifests struct {
+	Version interface{}            `yaml:"version,omitempty"`
+	Dev     map[string]interface{} `yaml:"dev"`
+}
+
+// manifestSchema are the fields of a manifest file before its includes, development containers and defaults are resolved
+type manifestSchema struct {
+	Dev     `yaml:",inline"`
+	Include []string              `yaml:"include,omitempty"`
+	Devs    map[string]*devSchema `yaml:"dev,omitempty"`
+}
+
+// devSchema is a development container of the 'dev' field. Its error is kept, so only the one of the selected development container is reported
+type devSchema struct {
+	err error
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (d *devSchema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	d.err = unmarshal(&Dev{})
+	return nil
+}
+
+// checkManifest returns the error of the manifest file b, or of its development container called name.
+// The line numbers of the error are the ones of b, and not the ones of the manifest generated from it
+func checkManifest(b []byte, name string) error {
+	schema := manifestSchema{}
+	if err := yaml.UnmarshalStrict(b, &schema); err != nil {
+		return manifestError(err)
+	}
+
+	if d, ok := schema.Devs[name]; ok && d.err != nil {
+		return manifestError(d.err)
+	}
+
+	return nil
+}
+
+// selectDev returns the definition of the development container called name and its name.
+// Manifests without a top-level 'dev' field define a single development container and are returned as is.
+// If name is empty, the 'dev' field must define a single development container
+func selectDev(b []byte, name string) ([]byte, string, error) {
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(b, &fields); err != nil {
+		// Parse reports a better error
+		return b, "", nil
+	}
+
+	if _, ok := fields[devManifestsField]; !ok {
+		return b, "", nil
+	}
+
+	version, hasVersion := fields[manifestVersionField]
+	if hasVersion {
+		delete(fields, manifestVersionField)
+	}
+
+	if len(fields) > 1 {
+		return nil, "", fmt.Errorf("invalid manifest: '%s' can only be combined with '%s'", devManifestsField, manifestVersionField)
+	}
+
+	manifests := devManifests{}
+	if err := yaml.UnmarshalStrict(b, &manifests); err != nil {
+		return nil, "", fmt.Errorf("invalid manifest: '%s' must be a map of development containers", devManifestsField)
+	}
+
+	if len(manifests.Dev) == 0 {
+		return nil, "", fmt.Errorf("invalid manifest: '%s' doesn't define any development container", devManifestsField)
+	}
+
+	names := make([]string, 0, len(manifests.Dev))
+	for n := range manifests.Dev {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	if name == "" {
+		if len(names) > 1 {
+			return nil, "", fmt.Errorf("your manifest defines several development containers, select one of: %s", strings.Join(names, ", "))
+		}
+		name = names[0]
+	}
+
+	d, ok := manifests.Dev[name]
+	if !ok {
+		return nil, "", fmt.Errorf("'%s' is not defined in your manifest, available development containers: %s", name, strings.Join(names, ", "))
+	}
+
+	// the top-level version applies to every development container
+	if m, ok := d.(map[interface{}]interface{}); ok && hasVersion {
+		if _, ok := m[manifestVersionField]; !ok {
+			m[manifestVersionField] = version
+		}
+	}
+
+	out, err := yaml.Marshal(d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return out, name, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_selectDev(t *testing.T) {
+	var tests = []struct {
+		name      string
+		manifest  string
+		service   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "single",
+			manifest: "name: api\nimage: okteto/golang:1\n",
+			expected: "",
+		},
+		{
+			name:     "single-with-name",
+			manifest: "name: api\nimage: okteto/golang:1\n",
+			service:  "api",
+			expected: "",
+		},
+		{
+			name:     "multiple-one-service",
+			manifest: "dev:\n  api:\n    image: okteto/golang:1\n",
+			expected: "api",
+		},
+		{
+			name:     "multiple-selected",
+			manifest: "dev:\n  api:\n    image: okteto/golang:1\n  frontend:\n    image: okteto/node:12\n",
+			service:  "frontend",
+			expected: "frontend",
+		},
+		{
+			name:      "multiple-not-selected",
+			manifest:  "dev:\n  api:\n    image: okteto/golang:1\n  frontend:\n    image: okteto/node:12\n",
+			expectErr: true,
+		},
+		{
+			name:      "multiple-wrong-name",
+			manifest:  "dev:\n  api:\n    image: okteto/golang:1\n",
+			service:   "worker",
+			expectErr: true,
+		},
+		{
+			name:     "multiple-with-version",
+			manifest: "version: 2\ndev:\n  api:\n    image: okteto/golang:1\n",
+			expected: "api",
+		},
+		{
+			name:      "multiple-with-other-fields",
+			manifest:  "name: api\ndev:\n  api:\n    image: okteto/golang:1\n",
+			expectErr: true,
+		},
+		{
+			name:      "multiple-empty",
+			manifest:  "dev: {}\n",
+			expectErr: true,
+		},
+		{
+			name:      "multiple-not-a-map",
+			manifest:  "dev:\n  - api\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := selectDev([]byte(tt.manifest), tt.service)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetService(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := []byte(`dev:
+  api:
+    image: okteto/golang:1
+    command: ["go", "run", "main.go"]
+    sync:
+      - .:/app
+  frontend:
+    name: web
+    image: okteto/node:12
+    sync:
+      - .:/src`)
+
+	p := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(p, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := GetService(p, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if api.Name != "api" {
+		t.Errorf("got name %s, expected api", api.Name)
+	}
+
+	if api.Image.Name != "okteto/golang:1" {
+		t.Errorf("got image %s, expected okteto/golang:1", api.Image.Name)
+	}
+
+	if api.Sync.Folders[0].LocalPath != dir {
+		t.Errorf("got local path %s, expected %s", api.Sync.Folders[0].LocalPath, dir)
+	}
+
+	frontend, err := GetService(p, "frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frontend.Name != "web" {
+		t.Errorf("got name %s, expected web", frontend.Name)
+	}
+
+	if _, err := Get(p); err == nil {
+		t.Error("expected an error when no development container is selected")
+	}
+}
+
+func TestGetServiceErrorLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := []byte(`dev:
+  api:
+    image: okteto/golang:1
+  frontend:
+    image: okteto/node:12
+    syncs:
+      - .:/src`)
+
+	p := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(p, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetService(p, "api"); err != nil {
+		t.Fatalf("the error of another development container was reported: %s", err)
+	}
+
+	_, err = GetService(p, "frontend")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), "line 6: field syncs not found") {
+		t.Errorf("the error doesn't point to the line of the manifest: %s", err)
+	}
+}
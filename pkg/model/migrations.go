@@ -0,0 +1,140 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	manifestVersionField = "version"
+
+	// oldestManifestVersion is the schema of the manifests that don't declare a version
+	oldestManifestVersion = 1
+
+	// CurrentManifestVersion is the latest schema of the okteto manifest supported by this binary
+	CurrentManifestVersion = 2
+)
+
+// manifestMigration upgrades a manifest to the next version of the schema
+type manifestMigration struct {
+	// version is the schema version the manifest is upgraded to
+	version int
+
+	// warning is shown when the migration modifies the manifest
+	warning string
+
+	// migrate transforms the development container in place and returns if it was modified
+	migrate func(dev *Dev) bool
+}
+
+// manifestMigrations are applied in order, starting with the first one newer than the version of the manifest
+var manifestMigrations = []manifestMigration{
+	{
+		version: 2,
+		warning: fmt.Sprintf("The syntax 'localPath:remotePath' is deprecated in the 'volumes' field, the folders were moved to the field 'sync' (%s)", syncFieldDocsURL),
+		migrate: migrateVolumesToSync,
+	},
+}
+
+// getConfigVersion returns the schema version declared by the manifest fields m.
+// Manifests without a 'version' field use the oldest schema
+func getConfigVersion(m map[interface{}]interface{}) (int, error) {
+	v, ok := m[manifestVersionField]
+	if !ok || v == nil {
+		return oldestManifestVersion, nil
+	}
+
+	version, ok := v.(int)
+	if !ok {
+		return 0, fmt.Errorf("'%s' must be a number between %d and %d", manifestVersionField, oldestManifestVersion, CurrentManifestVersion)
+	}
+
+	return checkConfigVersion(version)
+}
+
+// checkConfigVersion returns version, or the oldest schema if it isn't set, if this binary supports it
+func checkConfigVersion(version int) (int, error) {
+	if version == 0 {
+		return oldestManifestVersion, nil
+	}
+
+	if version < oldestManifestVersion {
+		return 0, fmt.Errorf("'%s' must be a number between %d and %d", manifestVersionField, oldestManifestVersion, CurrentManifestVersion)
+	}
+
+	if version > CurrentManifestVersion {
+		return 0, errors.UserError{
+			E:    fmt.Errorf("your manifest uses version %d of the okteto manifest, but this version of okteto only supports up to version %d", version, CurrentManifestVersion),
+			Hint: "Upgrade okteto to the latest version and try again",
+		}
+	}
+
+	return version, nil
+}
+
+// migrate upgrades dev to the current schema, transforming the deprecated fields.
+// Manifests that don't need any migration are left as they are
+func (dev *Dev) migrate() error {
+	version, err := checkConfigVersion(dev.Version)
+	if err != nil {
+		return err
+	}
+
+	migrated := false
+	for _, migration := range manifestMigrations {
+		if migration.version <= version {
+			continue
+		}
+
+		if migration.migrate(dev) {
+			log.Yellow(migration.warning)
+			log.Debugf("manifest migrated from version %d to version %d", version, migration.version)
+			migrated = true
+		}
+	}
+
+	if migrated {
+		dev.Version = CurrentManifestVersion
+	}
+
+	return nil
+}
+
+// migrateVolumesToSync moves the volumes with the syntax 'localPath:remotePath' to 'sync'
+func migrateVolumesToSync(dev *Dev) bool {
+	migrated := hasLocalVolumes(dev)
+	dev.translateDeprecatedVolumes()
+	for _, s := range dev.Services {
+		if hasLocalVolumes(s) {
+			migrated = true
+		}
+		s.translateDeprecatedVolumes()
+	}
+
+	return migrated
+}
+
+func hasLocalVolumes(dev *Dev) bool {
+	for _, v := range dev.Volumes {
+		if v.LocalPath != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func getManifestVersion(b []byte) (int, error) {
+	m := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return 0, err
+	}
+
+	return getConfigVersion(m)
+}
+
+func Test_getConfigVersion(t *testing.T) {
+	var tests = []struct {
+		name      string
+		manifest  string
+		expected  int
+		expectErr bool
+	}{
+		{
+			name:     "no-version",
+			manifest: "name: api\n",
+			expected: oldestManifestVersion,
+		},
+		{
+			name:     "null-version",
+			manifest: "version:\nname: api\n",
+			expected: oldestManifestVersion,
+		},
+		{
+			name:     "current-version",
+			manifest: "version: 2\nname: api\n",
+			expected: CurrentManifestVersion,
+		},
+		{
+			name:      "newer-version",
+			manifest:  "version: 3\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name:      "zero-version",
+			manifest:  "version: 0\nname: api\n",
+			expectErr: true,
+		},
+		{
+			name:      "not-a-number",
+			manifest:  "version: v2\nname: api\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getManifestVersion([]byte(tt.manifest))
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("got %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_migrate(t *testing.T) {
+	var tests = []struct {
+		name            string
+		manifest        string
+		expectedSync    []SyncFolder
+		expectedVolumes []Volume
+		expectedVersion int
+	}{
+		{
+			name:         "no-volumes",
+			manifest:     "name: api\nsync:\n- /app:/app\n",
+			expectedSync: []SyncFolder{{LocalPath: "/app", RemotePath: "/app"}},
+		},
+		{
+			name:            "remote-volumes",
+			manifest:        "name: api\nvolumes:\n- /go/pkg\n",
+			expectedSync:    []SyncFolder{},
+			expectedVolumes: []Volume{{RemotePath: "/go/pkg"}},
+		},
+		{
+			name:            "volumes",
+			manifest:        "name: api\nsync:\n- /app:/app\nvolumes:\n- /docs:/docs\n- /go/pkg\n",
+			expectedSync:    []SyncFolder{{LocalPath: "/app", RemotePath: "/app"}, {LocalPath: "/docs", RemotePath: "/docs"}},
+			expectedVolumes: []Volume{{RemotePath: "/go/pkg"}},
+			expectedVersion: CurrentManifestVersion,
+		},
+		{
+			name:            "current-version",
+			manifest:        "version: 2\nname: api\nvolumes:\n- /app:/app\n",
+			expectedSync:    []SyncFolder{},
+			expectedVolumes: []Volume{{LocalPath: "/app", RemotePath: "/app"}},
+			expectedVersion: CurrentManifestVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Parse([]byte(tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := dev.migrate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(dev.Sync.Folders, tt.expectedSync) {
+				t.Errorf("got sync %+v, expected %+v", dev.Sync.Folders, tt.expectedSync)
+			}
+
+			if len(dev.Volumes) != len(tt.expectedVolumes) || (len(dev.Volumes) > 0 && !reflect.DeepEqual(dev.Volumes, tt.expectedVolumes)) {
+				t.Errorf("got volumes %+v, expected %+v", dev.Volumes, tt.expectedVolumes)
+			}
+
+			if dev.Version != tt.expectedVersion {
+				t.Errorf("got version %d, expected %d", dev.Version, tt.expectedVersion)
+			}
+		})
+	}
+}
+
+func Test_migrateServices(t *testing.T) {
+	dev, err := Parse([]byte("name: api\nsync:\n- /app:/app\nservices:\n- name: worker\n  volumes:\n  - /app/worker:/src\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.migrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Version != CurrentManifestVersion {
+		t.Errorf("got version %d, expected %d", dev.Version, CurrentManifestVersion)
+	}
+
+	s := dev.Services[0]
+	if len(s.Volumes) != 0 || len(s.Sync.Folders) != 1 || s.Sync.Folders[0].RemotePath != "/src" {
+		t.Errorf("the volume of the service wasn't migrated: %+v %+v", s.Volumes, s.Sync.Folders)
+	}
+}
+
+func Test_migrateNewerVersion(t *testing.T) {
+	dev := &Dev{Version: 10}
+	err := dev.migrate()
+	if err == nil {
+		t.Fatal("didn't get the expected error")
+	}
+
+	if !strings.Contains(err.Error(), "only supports up to version 2") {
+		t.Errorf("the error doesn't mention the supported version: %s", err)
+	}
+}
+
+func Test_ReadMigratedManifest(t *testing.T) {
+	manifest := `name: api
+image: okteto/golang:1
+volumes:
+  - .:/app
+  - /go/pkg`
+
+	dev, err := Read(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Version != CurrentManifestVersion {
+		t.Errorf("got version %d, expected %d", dev.Version, CurrentManifestVersion)
+	}
+
+	if len(dev.Sync.Folders) != 1 || dev.Sync.Folders[0].RemotePath != "/app" {
+		t.Errorf("the volume wasn't migrated to sync: %+v", dev.Sync.Folders)
+	}
+
+	if len(dev.Volumes) != 1 || dev.Volumes[0].RemotePath != "/go/pkg" {
+		t.Errorf("the remote volume wasn't kept: %+v", dev.Volumes)
+	}
+}
+
+func Test_selectDevVersion(t *testing.T) {
+	manifest := "version: 2\ndev:\n  api:\n    image: okteto/golang:1\n  frontend:\n    version: 1\n    image: okteto/node:12\n"
+
+	b, _, err := selectDev([]byte(manifest), "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := getManifestVersion(b); err != nil || v != 2 {
+		t.Errorf("got version %d (%v), expected the top-level version", v, err)
+	}
+
+	b, _, err = selectDev([]byte(manifest), "frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := getManifestVersion(b); err != nil || v != 1 {
+		t.Errorf("got version %d (%v), expected the version of the development container", v, err)
+	}
+}
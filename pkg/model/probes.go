@@ -0,0 +1,131 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Probes represents the probes of the development container
+type Probes struct {
+	Liveness  *Probe `json:"liveness,omitempty" yaml:"liveness,omitempty"`
+	Readiness *Probe `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+	Startup   *Probe `json:"startup,omitempty" yaml:"startup,omitempty"`
+}
+
+// Probe represents a health check of the development container
+type Probe struct {
+	HTTPGet             *HTTPGetProbe   `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+	TCPSocket           *TCPSocketProbe `json:"tcpSocket,omitempty" yaml:"tcpSocket,omitempty"`
+	Exec                *ExecProbe      `json:"exec,omitempty" yaml:"exec,omitempty"`
+	InitialDelaySeconds int32           `json:"initialDelaySeconds,omitempty" yaml:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int32           `json:"periodSeconds,omitempty" yaml:"periodSeconds,omitempty"`
+	TimeoutSeconds      int32           `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+	SuccessThreshold    int32           `json:"successThreshold,omitempty" yaml:"successThreshold,omitempty"`
+	FailureThreshold    int32           `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+}
+
+// HTTPGetProbe represents a probe based on an http request
+type HTTPGetProbe struct {
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port   int    `json:"port,omitempty" yaml:"port,omitempty"`
+	Host   string `json:"host,omitempty" yaml:"host,omitempty"`
+	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+}
+
+// TCPSocketProbe represents a probe based on opening a tcp connection
+type TCPSocketProbe struct {
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+	Host string `json:"host,omitempty" yaml:"host,omitempty"`
+}
+
+// ExecProbe represents a probe based on running a command in the development container
+type ExecProbe struct {
+	Command Command `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+func validateProbes(p *Probes) error {
+	if p == nil {
+		return nil
+	}
+
+	if err := validateProbe("liveness", p.Liveness); err != nil {
+		return err
+	}
+
+	if err := validateProbe("readiness", p.Readiness); err != nil {
+		return err
+	}
+
+	return validateProbe("startup", p.Startup)
+}
+
+func validateProbe(name string, p *Probe) error {
+	if p == nil {
+		return nil
+	}
+
+	handlers := 0
+	if p.HTTPGet != nil {
+		handlers++
+		if !isValidPort(p.HTTPGet.Port) {
+			return fmt.Errorf("'probes.%s.httpGet.port' must be between 1 and %d", name, maxPort)
+		}
+
+		switch strings.ToUpper(p.HTTPGet.Scheme) {
+		case "", "HTTP", "HTTPS":
+		default:
+			return fmt.Errorf("supported values for 'probes.%s.httpGet.scheme' are: 'HTTP' or 'HTTPS'", name)
+		}
+	}
+
+	if p.TCPSocket != nil {
+		handlers++
+		if !isValidPort(p.TCPSocket.Port) {
+			return fmt.Errorf("'probes.%s.tcpSocket.port' must be between 1 and %d", name, maxPort)
+		}
+	}
+
+	if p.Exec != nil {
+		handlers++
+		if len(p.Exec.Command.Values) == 0 {
+			return fmt.Errorf("'probes.%s.exec.command' cannot be empty", name)
+		}
+	}
+
+	if handlers != 1 {
+		return fmt.Errorf("'probes.%s' must define one of 'httpGet', 'tcpSocket' or 'exec'", name)
+	}
+
+	values := []struct {
+		field string
+		value int32
+	}{
+		{field: "initialDelaySeconds", value: p.InitialDelaySeconds},
+		{field: "periodSeconds", value: p.PeriodSeconds},
+		{field: "timeoutSeconds", value: p.TimeoutSeconds},
+		{field: "successThreshold", value: p.SuccessThreshold},
+		{field: "failureThreshold", value: p.FailureThreshold},
+	}
+
+	// zero values aren't set, Kubernetes applies its defaults to them
+	for _, v := range values {
+		if v.value < 0 {
+			return fmt.Errorf("'probes.%s.%s' must not be negative", name, v.field)
+		}
+	}
+
+	return nil
+}
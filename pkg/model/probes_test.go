@@ -0,0 +1,137 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_probesUnmarshalling(t *testing.T) {
+	manifest := []byte(`name: web
+probes:
+  readiness:
+    httpGet:
+      path: /healthz
+      port: 8080
+    periodSeconds: 5
+  liveness:
+    exec:
+      command: ["cat", "/tmp/healthy"]
+  startup:
+    tcpSocket:
+      port: 8080
+    failureThreshold: 30`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Probes{
+		Readiness: &Probe{
+			HTTPGet:       &HTTPGetProbe{Path: "/healthz", Port: 8080},
+			PeriodSeconds: 5,
+		},
+		Liveness: &Probe{
+			Exec: &ExecProbe{Command: Command{Values: []string{"cat", "/tmp/healthy"}}},
+		},
+		Startup: &Probe{
+			TCPSocket:        &TCPSocketProbe{Port: 8080},
+			FailureThreshold: 30,
+		},
+	}
+
+	if !reflect.DeepEqual(dev.Probes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dev.Probes)
+	}
+}
+
+func Test_validateProbes(t *testing.T) {
+	var tests = []struct {
+		name      string
+		probes    *Probes
+		expectErr bool
+	}{
+		{
+			name:      "nil",
+			probes:    nil,
+			expectErr: false,
+		},
+		{
+			name: "valid",
+			probes: &Probes{
+				Readiness: &Probe{HTTPGet: &HTTPGetProbe{Path: "/", Port: 8080, Scheme: "https"}, PeriodSeconds: 5, TimeoutSeconds: 1},
+				Liveness:  &Probe{TCPSocket: &TCPSocketProbe{Port: 8080}},
+				Startup:   &Probe{Exec: &ExecProbe{Command: Command{Values: []string{"true"}}}},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "no-handler",
+			probes:    &Probes{Readiness: &Probe{PeriodSeconds: 5}},
+			expectErr: true,
+		},
+		{
+			name: "several-handlers",
+			probes: &Probes{
+				Liveness: &Probe{HTTPGet: &HTTPGetProbe{Port: 8080}, TCPSocket: &TCPSocketProbe{Port: 8080}},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "wrong-port",
+			probes:    &Probes{Startup: &Probe{TCPSocket: &TCPSocketProbe{Port: 70000}}},
+			expectErr: true,
+		},
+		{
+			name:      "wrong-scheme",
+			probes:    &Probes{Readiness: &Probe{HTTPGet: &HTTPGetProbe{Port: 8080, Scheme: "ftp"}}},
+			expectErr: true,
+		},
+		{
+			name:      "empty-command",
+			probes:    &Probes{Liveness: &Probe{Exec: &ExecProbe{}}},
+			expectErr: true,
+		},
+		{
+			name:      "negative-period",
+			probes:    &Probes{Readiness: &Probe{TCPSocket: &TCPSocketProbe{Port: 8080}, PeriodSeconds: -1}},
+			expectErr: true,
+		},
+		{
+			name:      "zero-period-and-timeout",
+			probes:    &Probes{Readiness: &Probe{TCPSocket: &TCPSocketProbe{Port: 8080}, PeriodSeconds: 0, TimeoutSeconds: 0}},
+			expectErr: false,
+		},
+		{
+			name:      "negative-timeout",
+			probes:    &Probes{Readiness: &Probe{TCPSocket: &TCPSocketProbe{Port: 8080}, TimeoutSeconds: -5}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProbes(tt.probes)
+			if tt.expectErr && err == nil {
+				t.Error("didn't got the expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Restart runs a command in the development container when the synchronized files that match Paths change,
+// to restart processes that don't reload the code by themselves
+type Restart struct {
+	Paths   []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Command Command  `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// Matches returns true if p, relative to the synchronized folder, matches any of the paths of r.
+// Patterns without a slash, like *.go, are matched against the name of the file in any folder
+func (r *Restart) Matches(p string) bool {
+	if r == nil {
+		return false
+	}
+
+	p = strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "/")
+	for _, pattern := range r.Paths {
+		target := p
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(p)
+		}
+
+		if ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateRestart(r *Restart) error {
+	if r == nil {
+		return nil
+	}
+
+	if len(r.Paths) == 0 {
+		return fmt.Errorf("'restart.paths' cannot be empty")
+	}
+
+	for _, p := range r.Paths {
+		if p == "" {
+			return fmt.Errorf("'restart.paths' cannot contain empty paths")
+		}
+
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("'restart.paths' contains an invalid pattern '%s': %s", p, err)
+		}
+	}
+
+	if len(r.Command.Values) == 0 {
+		return fmt.Errorf("'restart.command' cannot be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_restartUnmarshalling(t *testing.T) {
+	manifest := []byte(`name: web
+restart:
+  paths:
+    - "*.go"
+    - config/*.yaml
+  command: pkill -f /app/server`)
+
+	dev, err := Parse(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"*.go", "config/*.yaml"}
+	if !reflect.DeepEqual(dev.Restart.Paths, expected) {
+		t.Errorf("expected %v, got %v", expected, dev.Restart.Paths)
+	}
+
+	command := []string{"sh", "-c", "pkill -f /app/server"}
+	if !reflect.DeepEqual(dev.Restart.Command.Values, command) {
+		t.Errorf("expected %v, got %v", command, dev.Restart.Command.Values)
+	}
+}
+
+func TestRestart_Matches(t *testing.T) {
+	r := &Restart{Paths: []string{"*.go", "config/*.yaml", "/Makefile"}}
+	var tests = []struct {
+		path     string
+		expected bool
+	}{
+		{path: "main.go", expected: true},
+		{path: "pkg/server/server.go", expected: true},
+		{path: `pkg\server\server.go`, expected: true},
+		{path: "config/app.yaml", expected: true},
+		{path: "deploy/config/app.yaml", expected: false},
+		{path: "Makefile", expected: true},
+		{path: "README.md", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := r.Matches(tt.path); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+
+	var none *Restart
+	if none.Matches("main.go") {
+		t.Error("a nil restart matched a path")
+	}
+}
+
+func Test_validateRestart(t *testing.T) {
+	command := Command{Values: []string{"pkill", "server"}}
+	var tests = []struct {
+		name      string
+		restart   *Restart
+		expectErr bool
+	}{
+		{
+			name:      "nil",
+			restart:   nil,
+			expectErr: false,
+		},
+		{
+			name:      "valid",
+			restart:   &Restart{Paths: []string{"*.go"}, Command: command},
+			expectErr: false,
+		},
+		{
+			name:      "no-paths",
+			restart:   &Restart{Command: command},
+			expectErr: true,
+		},
+		{
+			name:      "empty-path",
+			restart:   &Restart{Paths: []string{""}, Command: command},
+			expectErr: true,
+		},
+		{
+			name:      "invalid-pattern",
+			restart:   &Restart{Paths: []string{"[*.go"}, Command: command},
+			expectErr: true,
+		},
+		{
+			name:      "no-command",
+			restart:   &Restart{Paths: []string{"*.go"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRestart(tt.restart)
+			if tt.expectErr && err == nil {
+				t.Error("expected error but got none")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/pkg/log"
 	apiv1 "k8s.io/api/core/v1"
@@ -34,43 +35,106 @@ type buildInfoRaw struct {
 	Args       []EnvVar `yaml:"args,omitempty"`
 }
 
+type envVarRaw EnvVar
+
+type stackEnvVar EnvVar
+
 type syncRaw struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
-	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	RescanInterval Duration     `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Ignore         []string     `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+	Gitignore      bool         `json:"gitignore,omitempty" yaml:"gitignore,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+type secretRaw struct {
+	LocalPath  string `yaml:"localPath"`
+	RemotePath string `yaml:"remotePath"`
+	Mode       string `yaml:"mode,omitempty"`
+}
+
+type externalVolumeRaw struct {
+	ClaimName string `yaml:"claimName"`
+	SubPath   string `yaml:"subPath,omitempty"`
+	MountPath string `yaml:"mountPath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type persistentVolumeInfoRaw PersistentVolumeInfo
+
 type storageResourceRaw struct {
 	Size  Quantity `json:"size,omitempty" yaml:"size,omitempty"`
 	Class string   `json:"class,omitempty" yaml:"class,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Environment variables are defined with the syntax 'NAME=value', or as an object to read the value from the cluster
 func (e *EnvVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return e.unmarshal(unmarshal, func(value string) (string, error) {
+		return expandEnvField("environment", value)
+	})
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Undefined variables in the environment of a stack service expand to an empty string
+func (e *StackEnvironment) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw []stackEnvVar
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	*e = make(StackEnvironment, 0, len(raw))
+	for _, v := range raw {
+		*e = append(*e, EnvVar(v))
+	}
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (e *stackEnvVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return (*EnvVar)(e).unmarshal(unmarshal, ExpandEnvLenient)
+}
+
+func (e *EnvVar) unmarshal(unmarshal func(interface{}) error, expand func(string) (string, error)) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
+		var rawEnvVar envVarRaw
+		if err := unmarshal(&rawEnvVar); err != nil {
+			return err
+		}
+
+		if rawEnvVar.Name == "" {
+			return fmt.Errorf("'name' is required in the environment variables defined as objects")
+		}
+
+		e.Name = rawEnvVar.Name
+		e.ValueFrom = rawEnvVar.ValueFrom
+		e.Value, err = expand(rawEnvVar.Value)
 		return err
 	}
 
 	parts := strings.SplitN(raw, "=", 2)
 	e.Name = parts[0]
 	if len(parts) == 2 {
-		e.Value, err = ExpandEnv(parts[1])
+		e.Value, err = expand(parts[1])
 		if err != nil {
 			return err
 		}
 		return nil
 	}
 
-	e.Name, err = ExpandEnv(parts[0])
+	e.Name, err = expand(parts[0])
 	return err
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (e EnvVar) MarshalYAML() (interface{}, error) {
+	if e.ValueFrom != nil {
+		return envVarRaw(e), nil
+	}
 	return e.Name + "=" + e.Value, nil
 }
 
@@ -86,6 +150,7 @@ func (c *Command) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		if strings.Contains(single, " ") {
 			c.Values = []string{"sh", "-c", single}
+			c.shell = true
 		} else {
 			c.Values = []string{single}
 		}
@@ -97,6 +162,9 @@ func (c *Command) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (c Command) MarshalYAML() (interface{}, error) {
+	if c.shell && len(c.Values) == 3 {
+		return c.Values[2], nil
+	}
 	if len(c.Values) == 1 && !strings.Contains(c.Values[0], " ") {
 		return c.Values[0], nil
 	}
@@ -125,6 +193,35 @@ func (a Args) MarshalYAML() (interface{}, error) {
 	return a.Values, nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		seconds, atoiErr := strconv.Atoi(raw)
+		if atoiErr != nil {
+			return fmt.Errorf("'%s' is not a valid duration", raw)
+		}
+		parsed = time.Duration(seconds) * time.Second
+	}
+
+	if parsed <= 0 {
+		return fmt.Errorf("'%s' is not a positive duration", raw)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var rawFolders []SyncFolder
@@ -142,17 +239,40 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	sync.Compression = rawSync.Compression
-	sync.RescanInterval = rawSync.RescanInterval
+	// syncthing rescans in seconds, shorter intervals are rounded up
+	sync.RescanInterval = int((time.Duration(rawSync.RescanInterval) + time.Second - 1) / time.Second)
 	sync.Folders = rawSync.Folders
+	sync.Ignore = rawSync.Ignore
+	sync.Gitignore = rawSync.Gitignore
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && !sync.HasIgnores() {
 		return sync.Folders, nil
 	}
-	return syncRaw(sync), nil
+	return syncRaw{
+		Compression:    sync.Compression,
+		RescanInterval: Duration(time.Duration(sync.RescanInterval) * time.Second),
+		Folders:        sync.Folders,
+		Ignore:         sync.Ignore,
+		Gitignore:      sync.Gitignore,
+		LocalPath:      sync.LocalPath,
+		RemotePath:     sync.RemotePath,
+	}, nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// The persistent volume is enabled unless 'enabled' is set to false
+func (p *PersistentVolumeInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := persistentVolumeInfoRaw{Enabled: true}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	*p = PersistentVolumeInfo(raw)
+	return nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -247,44 +367,71 @@ func (q Quantity) MarshalYAML() (interface{}, error) {
 func (s *Secret) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
+	if err == nil {
+		parts := strings.Split(raw, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return fmt.Errorf("secrets must follow the syntax 'LOCAL_PATH:REMOTE_PATH:MODE'")
+		}
+		remotePath, err := expandEnvField("secrets", parts[1])
+		if err != nil {
+			return err
+		}
+		mode := ""
+		if len(parts) == 3 {
+			mode, err = expandEnvField("secrets", parts[2])
+			if err != nil {
+				return err
+			}
+		}
+		return s.setValues(parts[0], remotePath, mode)
+	}
+
+	var rawSecret secretRaw
+	err = unmarshal(&rawSecret)
 	if err != nil {
 		return err
 	}
 
-	rawExpanded, err := ExpandEnv(raw)
+	return s.setValues(rawSecret.LocalPath, rawSecret.RemotePath, rawSecret.Mode)
+}
+
+func (s *Secret) setValues(localPath, remotePath, mode string) error {
+	localPath, err := ExpandPath(localPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("'secrets': %s", err.Error())
 	}
-	parts := strings.Split(rawExpanded, ":")
-	if len(parts) < 2 || len(parts) > 3 {
-		return fmt.Errorf("secrets must follow the syntax 'LOCAL_PATH:REMOTE_PATH:MODE'")
+	s.LocalPath = localPath
+	if !FileExists(s.LocalPath) {
+		return fmt.Errorf("Secret local path '%s' does not exist", s.LocalPath)
 	}
-	s.LocalPath = parts[0]
 	if err := checkFileAndNotDirectory(s.LocalPath); err != nil {
 		return err
 	}
-	s.RemotePath = parts[1]
+	s.RemotePath = remotePath
 	if !strings.HasPrefix(s.RemotePath, "/") {
 		return fmt.Errorf("Secret remote path '%s' must be an absolute path", s.RemotePath)
 	}
-	if len(parts) == 3 {
-		mode, err := strconv.ParseInt(parts[2], 8, 32)
-		if err != nil {
-			return fmt.Errorf("error parsing secret '%s' mode: %s", parts[0], err)
-		}
-		s.Mode = int32(mode)
-	} else {
+	if mode == "" {
 		s.Mode = 420
+		return nil
 	}
+	m, err := strconv.ParseInt(mode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("error parsing secret '%s' mode: %s", localPath, err)
+	}
+	if m < 0 || m > 0777 {
+		return fmt.Errorf("error parsing secret '%s' mode: '%s' is not a valid file mode", localPath, mode)
+	}
+	s.Mode = int32(m)
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (s Secret) MarshalYAML() (interface{}, error) {
 	if s.Mode == 420 {
-		return fmt.Sprintf("%s:%s:%s", s.LocalPath, s.RemotePath, strconv.FormatInt(int64(s.Mode), 8)), nil
+		return fmt.Sprintf("%s:%s", s.LocalPath, s.RemotePath), nil
 	}
-	return fmt.Sprintf("%s:%s", s.LocalPath, s.RemotePath), nil
+	return fmt.Sprintf("%s:%s:%s", s.LocalPath, s.RemotePath, strconv.FormatInt(int64(s.Mode), 8)), nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -311,6 +458,9 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	f.Local = localPort
 	f.Remote = remotePort
+	if !isValidPort(f.Local) || !isValidPort(f.Remote) {
+		return fmt.Errorf("Invalid reverse '%s': ports must be between 1 and 65535", raw)
+	}
 	return nil
 }
 
@@ -334,7 +484,7 @@ func (r *ResourceList) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	for k, v := range raw {
 		parsed, err := resource.ParseQuantity(v)
 		if err != nil {
-			return err
+			return fmt.Errorf("'%s' is not a valid quantity for resource '%s'", v, k)
 		}
 
 		(*r)[k] = parsed
@@ -364,7 +514,7 @@ func (v *Volume) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	parts := strings.SplitN(raw, ":", 2)
 	if len(parts) == 2 {
 		log.Yellow("The syntax '%s' is deprecated in the 'volumes' field. Use the field 'sync' instead (%s)", raw, syncFieldDocsURL)
-		v.LocalPath, err = ExpandEnv(parts[0])
+		v.LocalPath, err = expandEnvField("volumes", parts[0])
 		if err != nil {
 			return err
 		}
@@ -390,7 +540,7 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	parts := strings.SplitN(raw, ":", 2)
 	if len(parts) == 2 {
-		s.LocalPath, err = ExpandEnv(parts[0])
+		s.LocalPath, err = expandLocalPath("sync", parts[0])
 		if err != nil {
 			return err
 		}
@@ -398,20 +548,39 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return nil
 	}
 
-	return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath'")
+	if raw == "" {
+		return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath'")
+	}
+
+	// the remote path defaults to 'workdir'
+	s.LocalPath, err = expandLocalPath("sync", raw)
+	return err
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (s SyncFolder) MarshalYAML() (interface{}, error) {
+	if s.RemotePath == "" {
+		return s.LocalPath, nil
+	}
 	return s.LocalPath + ":" + s.RemotePath, nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// External volumes are defined with the syntax 'name:subpath:mountpath', or as an object to set more options
 func (v *ExternalVolume) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawVolume externalVolumeRaw
+		if err := unmarshal(&rawVolume); err != nil {
+			return err
+		}
+
+		v.Name = rawVolume.ClaimName
+		v.SubPath = rawVolume.SubPath
+		v.MountPath = rawVolume.MountPath
+		v.ReadOnly = rawVolume.ReadOnly
+		return nil
 	}
 
 	parts := strings.SplitN(raw, ":", 3)
@@ -431,6 +600,9 @@ func (v *ExternalVolume) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (v ExternalVolume) MarshalYAML() (interface{}, error) {
+	if v.ReadOnly {
+		return externalVolumeRaw{ClaimName: v.Name, SubPath: v.SubPath, MountPath: v.MountPath, ReadOnly: v.ReadOnly}, nil
+	}
 	if v.SubPath == "" {
 		return v.Name + ":" + v.MountPath, nil
 	}
@@ -18,11 +18,14 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 func TestReverseMashalling(t *testing.T) {
@@ -52,6 +55,11 @@ func TestReverseMashalling(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:      "out-of-range",
+			data:      "70000:9000",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,6 +92,135 @@ func TestReverseMashalling(t *testing.T) {
 	}
 }
 
+func TestDurationMashalling(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  Duration
+		marshaled string
+		expectErr bool
+	}{
+		{
+			name:      "duration",
+			data:      "1m30s",
+			expected:  Duration(90 * time.Second),
+			marshaled: "1m30s",
+		},
+		{
+			name:      "seconds",
+			data:      "45",
+			expected:  Duration(45 * time.Second),
+			marshaled: "45s",
+		},
+		{
+			name:      "zero",
+			data:      "0",
+			expectErr: true,
+		},
+		{
+			name:      "negative",
+			data:      "-1m",
+			expectErr: true,
+		},
+		{
+			name:      "invalid",
+			data:      "soon",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Duration
+			if err := yaml.Unmarshal([]byte(tt.data), &result); err != nil {
+				if tt.expectErr {
+					return
+				}
+
+				t.Fatal(err)
+			}
+
+			if tt.expectErr {
+				t.Fatalf("expected error unmarshaling '%s', got %s", tt.data, time.Duration(result))
+			}
+
+			if result != tt.expected {
+				t.Errorf("didn't unmarshal correctly. Actual '%s', Expected '%s'", time.Duration(result), time.Duration(tt.expected))
+			}
+
+			out, err := yaml.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if outStr := strings.TrimSuffix(string(out), "\n"); outStr != tt.marshaled {
+				t.Errorf("didn't marshal correctly. Actual '%s', Expected '%s'", outStr, tt.marshaled)
+			}
+		})
+	}
+}
+
+func TestSyncUnmashalling(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  Sync
+		expectErr bool
+	}{
+		{
+			name:     "folders",
+			data:     "- .:/app",
+			expected: Sync{RescanInterval: DefaultSyncthingRescanInterval, Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}},
+		},
+		{
+			name:     "duration",
+			data:     "compression: true\nrescanInterval: 1m\nfolders:\n- .:/app",
+			expected: Sync{Compression: true, RescanInterval: 60, Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}},
+		},
+		{
+			name:     "seconds",
+			data:     "rescanInterval: 90\nfolders:\n- .:/app",
+			expected: Sync{RescanInterval: 90, Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}},
+		},
+		{
+			name:     "rounded-up",
+			data:     "rescanInterval: 1500ms\nfolders:\n- .:/app",
+			expected: Sync{RescanInterval: 2, Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}},
+		},
+		{
+			name:      "zero",
+			data:      "rescanInterval: 0\nfolders:\n- .:/app",
+			expectErr: true,
+		},
+		{
+			name:      "negative",
+			data:      "rescanInterval: -1m\nfolders:\n- .:/app",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Sync
+			if err := yaml.Unmarshal([]byte(tt.data), &result); err != nil {
+				if tt.expectErr {
+					return
+				}
+
+				t.Fatal(err)
+			}
+
+			if tt.expectErr {
+				t.Fatalf("expected error unmarshaling '%s'", tt.data)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestEnvVarMashalling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -121,9 +258,9 @@ func TestEnvVarMashalling(t *testing.T) {
 			EnvVar{Name: "noenv", Value: ""},
 		},
 		{
-			"key-with-env-var-not-defined",
-			[]byte(`noenv=$UNDEFINED`),
-			EnvVar{Name: "noenv", Value: ""},
+			"key-with-env-var-default",
+			[]byte(`noenv=${UNDEFINED:-default}`),
+			EnvVar{Name: "noenv", Value: "default"},
 		},
 		{
 			"just-env-var",
@@ -131,9 +268,19 @@ func TestEnvVarMashalling(t *testing.T) {
 			EnvVar{Name: "test_environment", Value: ""},
 		},
 		{
-			"just-env-var-undefined",
-			[]byte(`$UNDEFINED`),
-			EnvVar{Name: "", Value: ""},
+			"object-with-value",
+			[]byte(`{name: env, value: $DEV_ENV}`),
+			EnvVar{Name: "env", Value: "test_environment"},
+		},
+		{
+			"secret-key-ref",
+			[]byte(`{name: token, valueFrom: {secretKeyRef: {name: api, key: token}}}`),
+			EnvVar{Name: "token", ValueFrom: &EnvVarSource{SecretKeyRef: &KeySelector{Name: "api", Key: "token"}}},
+		},
+		{
+			"config-map-key-ref",
+			[]byte(`{name: level, valueFrom: {configMapKeyRef: {name: settings, key: log.level}}}`),
+			EnvVar{Name: "level", ValueFrom: &EnvVarSource{ConfigMapKeyRef: &KeySelector{Name: "settings", Key: "log.level"}}},
 		},
 	}
 
@@ -153,14 +300,46 @@ func TestEnvVarMashalling(t *testing.T) {
 				t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", result, tt.expected)
 			}
 
-			_, err := yaml.Marshal(&result)
+			out, err := yaml.Marshal(&result)
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			if result.ValueFrom != nil {
+				var reversed EnvVar
+				if err := yaml.Unmarshal(out, &reversed); err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(reversed, tt.expected) {
+					t.Errorf("didn't marshal correctly. Actual %+v, Expected %+v", reversed, tt.expected)
+				}
+			}
 		})
 	}
 }
 
+func TestEnvVarObjectWithoutName(t *testing.T) {
+	var result EnvVar
+	if err := yaml.Unmarshal([]byte(`{valueFrom: {secretKeyRef: {name: api, key: token}}}`), &result); err == nil {
+		t.Fatal("expected error when the name is missing")
+	}
+}
+
+func TestEnvVarUndefined(t *testing.T) {
+	os.Unsetenv("UNDEFINED")
+	for _, data := range []string{`noenv=$UNDEFINED`, `$UNDEFINED`} {
+		var result EnvVar
+		err := yaml.Unmarshal([]byte(data), &result)
+		if err == nil {
+			t.Fatalf("'%s' didn't fail to unmarshal", data)
+		}
+		if !strings.Contains(err.Error(), "'environment'") {
+			t.Errorf("error doesn't name the field: %s", err.Error())
+		}
+	}
+}
+
 func TestCommandUnmashalling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -175,13 +354,18 @@ func TestCommandUnmashalling(t *testing.T) {
 		{
 			"single-space",
 			[]byte("start.sh arg"),
-			Command{Values: []string{"sh", "-c", "start.sh arg"}},
+			Command{Values: []string{"sh", "-c", "start.sh arg"}, shell: true},
 		},
 		{
 			"multiple",
 			[]byte("['yarn', 'install']"),
 			Command{Values: []string{"yarn", "install"}},
 		},
+		{
+			"multiple-explicit-shell",
+			[]byte("['sh', '-c', 'yarn dev']"),
+			Command{Values: []string{"sh", "-c", "yarn dev"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -215,6 +399,16 @@ func TestCommandMashalling(t *testing.T) {
 			command:  Command{Values: []string{"yarn", "start"}},
 			expected: "- yarn\n- start\n",
 		},
+		{
+			name:     "shell-form",
+			command:  Command{Values: []string{"sh", "-c", "yarn dev"}, shell: true},
+			expected: "yarn dev\n",
+		},
+		{
+			name:     "exec-form",
+			command:  Command{Values: []string{"sh", "-c", "yarn dev"}},
+			expected: "- sh\n- -c\n- yarn dev\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,6 +473,12 @@ func TestSecretMashalling(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	if err := os.Setenv("HOME", filepath.Dir(file.Name())); err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		name          string
 		data          string
@@ -333,6 +533,36 @@ func TestSecretMashalling(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"mode-out-of-range",
+			fmt.Sprintf("%s:/remote:1777", file.Name()),
+			nil,
+			true,
+		},
+		{
+			"home",
+			fmt.Sprintf("~/%s:/remote", filepath.Base(file.Name())),
+			&Secret{LocalPath: file.Name(), RemotePath: "/remote", Mode: 420},
+			false,
+		},
+		{
+			"fields",
+			fmt.Sprintf("localPath: %s\nremotePath: /remote", file.Name()),
+			&Secret{LocalPath: file.Name(), RemotePath: "/remote", Mode: 420},
+			false,
+		},
+		{
+			"fields-with-mode",
+			"localPath: $TEST_HOME\nremotePath: /remote\nmode: 0400",
+			&Secret{LocalPath: file.Name(), RemotePath: "/remote", Mode: 256},
+			false,
+		},
+		{
+			"fields-wrong-remote",
+			fmt.Sprintf("localPath: %s\nremotePath: remote", file.Name()),
+			nil,
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -401,3 +631,123 @@ func TestVolumeMashalling(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalVolumeMashalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected ExternalVolume
+	}{
+		{
+			name:     "string",
+			data:     "dataset:/data",
+			expected: ExternalVolume{Name: "dataset", MountPath: "/data"},
+		},
+		{
+			name:     "string-with-subpath",
+			data:     "dataset:images:/data",
+			expected: ExternalVolume{Name: "dataset", SubPath: "images", MountPath: "/data"},
+		},
+		{
+			name:     "object",
+			data:     "claimName: dataset\nmountPath: /data\nsubPath: images\nreadOnly: true",
+			expected: ExternalVolume{Name: "dataset", SubPath: "images", MountPath: "/data", ReadOnly: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v ExternalVolume
+			if err := yaml.Unmarshal([]byte(tt.data), &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", v, tt.expected)
+			}
+
+			out, err := yaml.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result ExternalVolume
+			if err := yaml.Unmarshal(out, &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't marshal correctly. Actual %+v, Expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResourceListUnmashalling(t *testing.T) {
+	var result ResourceList
+	if err := yaml.Unmarshal([]byte("cpu: 500m\nmemory: 1Gi"), &result); err != nil {
+		t.Fatal(err)
+	}
+	if v := result[apiv1.ResourceCPU]; v.String() != "500m" {
+		t.Errorf("cpu was not parsed: %s", v.String())
+	}
+
+	err := yaml.Unmarshal([]byte("cpu: 500x"), &result)
+	if err == nil {
+		t.Fatal("invalid quantity didn't fail to unmarshal")
+	}
+	if !strings.Contains(err.Error(), "'500x'") {
+		t.Errorf("error doesn't name the quantity: %s", err.Error())
+	}
+}
+
+func TestPersistentVolumeInfoMashalling(t *testing.T) {
+	var tests = []struct {
+		name     string
+		data     string
+		expected PersistentVolumeInfo
+	}{
+		{
+			name:     "default-enabled",
+			data:     "size: 20Gi",
+			expected: PersistentVolumeInfo{Enabled: true, Size: "20Gi"},
+		},
+		{
+			name:     "disabled",
+			data:     "enabled: false",
+			expected: PersistentVolumeInfo{Enabled: false},
+		},
+		{
+			name:     "storage-class",
+			data:     "enabled: true\nstorageClass: standard",
+			expected: PersistentVolumeInfo{Enabled: true, StorageClass: "standard"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result PersistentVolumeInfo
+			if err := yaml.Unmarshal([]byte(tt.data), &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("didn't unmarshal correctly. Actual %+v, Expected %+v", result, tt.expected)
+			}
+
+			marshalled, err := yaml.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var again PersistentVolumeInfo
+			if err := yaml.Unmarshal(marshalled, &again); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(again, tt.expected) {
+				t.Errorf("didn't survive a round trip. Actual %+v, Expected %+v", again, tt.expected)
+			}
+		})
+	}
+}
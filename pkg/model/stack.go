@@ -47,7 +47,7 @@ type Service struct {
 	Replicas        int               `yaml:"replicas"`
 	Command         Command           `yaml:"command,omitempty"`
 	Args            Args              `yaml:"args,omitempty"`
-	Environment     []EnvVar          `yaml:"environment,omitempty"`
+	Environment     StackEnvironment  `yaml:"environment,omitempty"`
 	EnvFiles        []string          `yaml:"env_file,omitempty"`
 	CapAdd          []string          `yaml:"cap_add,omitempty"`
 	CapDrop         []string          `yaml:"cap_drop,omitempty"`
@@ -58,6 +58,9 @@ type Service struct {
 	Resources       ServiceResources  `yaml:"resources,omitempty"`
 }
 
+//StackEnvironment represents the environment variables of an okteto stack service
+type StackEnvironment []EnvVar
+
 //ServiceResources represents an okteto stack service resources
 type ServiceResources struct {
 	CPU     Quantity        `json:"cpu,omitempty" yaml:"cpu,omitempty"`
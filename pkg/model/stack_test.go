@@ -14,6 +14,7 @@
 package model
 
 import (
+	"os"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -132,6 +133,41 @@ services:
 	}
 }
 
+func Test_ReadStackUndefinedEnvironment(t *testing.T) {
+	os.Setenv("STACK_DEFINED", "defined")
+	defer os.Unsetenv("STACK_DEFINED")
+	os.Unsetenv("STACK_UNDEFINED")
+
+	manifest := []byte(`name: voting-app
+services:
+  vote:
+    image: okteto/vote:1
+    environment:
+      - DEFINED=${STACK_DEFINED}
+      - UNDEFINED=${STACK_UNDEFINED}
+      - name: OBJECT
+        value: ${STACK_UNDEFINED}`)
+	s, err := ReadStack(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []EnvVar{
+		{Name: "DEFINED", Value: "defined"},
+		{Name: "UNDEFINED", Value: ""},
+		{Name: "OBJECT", Value: ""},
+	}
+	env := s.Services["vote"].Environment
+	if len(env) != len(expected) {
+		t.Fatalf("wrong environment: %+v", env)
+	}
+	for i := range expected {
+		if env[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], env[i])
+		}
+	}
+}
+
 func TestStack_validate(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -20,14 +20,17 @@ import (
 
 //Translation represents the information for translating a deployment
 type Translation struct {
-	Interactive bool               `json:"interactive"`
-	Name        string             `json:"name"`
-	Version     string             `json:"version"`
-	Deployment  *appsv1.Deployment `json:"-"`
-	Annotations map[string]string  `json:"annotations,omitempty"`
-	Tolerations []apiv1.Toleration `json:"tolerations,omitempty"`
-	Replicas    int32              `json:"replicas"`
-	Rules       []*TranslationRule `json:"rules"`
+	Interactive      bool               `json:"interactive"`
+	Name             string             `json:"name"`
+	Version          string             `json:"version"`
+	Deployment       *appsv1.Deployment `json:"-"`
+	Annotations      map[string]string  `json:"annotations,omitempty"`
+	Labels           map[string]string  `json:"labels,omitempty"`
+	Tolerations      []apiv1.Toleration `json:"tolerations,omitempty"`
+	NodeSelector     map[string]string  `json:"nodeSelector,omitempty"`
+	ImagePullSecrets []string           `json:"imagePullSecrets,omitempty"`
+	Replicas         int32              `json:"replicas"`
+	Rules            []*TranslationRule `json:"rules"`
 }
 
 //TranslationRule represents how to apply a container translation in a deployment
@@ -44,10 +47,13 @@ type TranslationRule struct {
 	Args              []string             `json:"args,omitempty"`
 	WorkDir           string               `json:"workdir"`
 	Healthchecks      bool                 `json:"healthchecks" yaml:"healthchecks"`
+	Probes            *Probes              `json:"probes,omitempty"`
+	Lifecycle         *Lifecycle           `json:"lifecycle,omitempty"`
 	PersistentVolume  bool                 `json:"persistentVolume" yaml:"persistentVolume"`
 	Volumes           []VolumeMount        `json:"volumes,omitempty"`
 	SecurityContext   *SecurityContext     `json:"securityContext,omitempty"`
 	Resources         ResourceRequirements `json:"resources,omitempty"`
+	InitContainer     *InitContainer       `json:"initContainer,omitempty"`
 }
 
 //VolumeMount represents a volume mount
@@ -55,6 +61,7 @@ type VolumeMount struct {
 	Name      string `json:"name,omitempty"`
 	MountPath string `json:"mountpath,omitempty"`
 	SubPath   string `json:"subpath,omitempty"`
+	ReadOnly  bool   `json:"readonly,omitempty"`
 }
 
 //IsSyncthing returns the volume mount is for syncthing
@@ -48,7 +48,7 @@ services:
       - worker:/src
     healthchecks: true`)
 
-	dev, err := Read(manifest)
+	dev, err := Parse(manifest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -37,6 +37,69 @@ func FileExists(name string) bool {
 	return true
 }
 
+// userHomeDir returns the folder that '~' expands to, it's a variable so tests can replace it
+var userHomeDir = os.UserHomeDir
+
+// ExpandPath expands the environment variables and a leading '~' of p, and returns it as a clean absolute path.
+// Relative paths are resolved from the working directory, and absolute paths are returned untouched.
+// It returns an error for the '~user' form, it's not supported
+func ExpandPath(p string) (string, error) {
+	expanded, err := ExpandEnv(p)
+	if err != nil {
+		return "", err
+	}
+
+	expanded, err = expandHomeDir(expanded)
+	if err != nil {
+		return "", err
+	}
+
+	if filepath.IsAbs(expanded) {
+		return expanded, nil
+	}
+
+	abs, err := filepath.Abs(expanded)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the absolute path of '%s': %w", p, err)
+	}
+
+	return abs, nil
+}
+
+// expandLocalPath expands the environment variables and a leading '~' of a local path of the manifest.
+// Relative paths are kept, they are resolved from the folder of the manifest by loadAbsPath
+func expandLocalPath(field, p string) (string, error) {
+	expanded, err := expandEnvField(field, p)
+	if err != nil {
+		return "", err
+	}
+
+	expanded, err = expandHomeDir(expanded)
+	if err != nil {
+		return "", fmt.Errorf("'%s': %s", field, err.Error())
+	}
+
+	return expanded, nil
+}
+
+func expandHomeDir(p string) (string, error) {
+	if !strings.HasPrefix(p, "~") {
+		return p, nil
+	}
+
+	rest := p[1:]
+	if rest != "" && !os.IsPathSeparator(rest[0]) {
+		return "", fmt.Errorf("cannot expand '%s': only '~' is supported, use the full path of the home of other users", p)
+	}
+
+	home, err := userHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot expand '~' in '%s': %s", p, err)
+	}
+
+	return filepath.Join(home, rest), nil
+}
+
 // CopyFile copies a binary between from and to
 func CopyFile(from, to string) error {
 	fromFile, err := os.Open(from)
@@ -66,9 +129,30 @@ func GetValidNameFromFolder(folder string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("error inferring name: %s", err)
 	}
-	name := filepath.Base(dir)
-	name = strings.ToLower(name)
-	name = ValidKubeNameRegex.ReplaceAllString(name, "-")
+	name := slugify(filepath.Base(dir))
 	log.Infof("autogenerated name: %s", name)
 	return name, nil
 }
+
+//InferName returns a valid kubernetes name for the folder of a path, so manifests without a name get the same one every time
+func InferName(path string) string {
+	name, err := GetValidNameFromFolder(path)
+	if err != nil {
+		log.Infof("%s", err)
+		name = slugify(filepath.Base(path))
+	}
+	if name == "" {
+		return defaultInferredName
+	}
+	return name
+}
+
+func slugify(value string) string {
+	name := strings.ToLower(value)
+	name = ValidKubeNameRegex.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-")
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-")
+	}
+	return name
+}
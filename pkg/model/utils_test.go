@@ -17,6 +17,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -108,3 +109,142 @@ func Test_GetValidNameFromFolder(t *testing.T) {
 		})
 	}
 }
+
+func Test_InferName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "simple", path: "/app/getting-started", expected: "getting-started"},
+		{name: "upper case and symbols", path: "/app/My_App.V2", expected: "my-app-v2"},
+		{name: "leading and trailing symbols", path: "/app/__app--", expected: "app"},
+		{name: "consecutive symbols", path: "/app/my__$app", expected: "my-app"},
+		{name: "too long", path: "/app/" + strings.Repeat("a", 60) + "-bcdef", expected: strings.Repeat("a", 60)},
+		{name: "no valid characters", path: "/app/___", expected: "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := InferName(tt.path)
+			if actual != tt.expected {
+				t.Errorf("got '%s' expected '%s'", actual, tt.expected)
+			}
+			if again := InferName(tt.path); again != actual {
+				t.Errorf("name is not stable: '%s' and '%s'", actual, again)
+			}
+		})
+	}
+}
+
+func Test_GetInferName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	folder := filepath.Join(dir, "My_Service")
+	if err := os.Mkdir(folder, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(folder, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("image: okteto/go:1\nsync:\n  - .:/app\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := Get(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Name != "my-service" {
+		t.Errorf("got '%s' expected 'my-service'", dev.Name)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	home := filepath.Join(os.TempDir(), "home")
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+	defer func() {
+		userHomeDir = os.UserHomeDir
+		os.Unsetenv("EXPAND_PATH_FOLDER")
+	}()
+	os.Setenv("EXPAND_PATH_FOLDER", "projects")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name      string
+		path      string
+		expected  string
+		expectErr bool
+	}{
+		{name: "home", path: "~", expected: home},
+		{name: "home-subfolder", path: "~/.ssh/id_rsa", expected: filepath.Join(home, ".ssh", "id_rsa")},
+		{name: "env", path: "~/${EXPAND_PATH_FOLDER}/app", expected: filepath.Join(home, "projects", "app")},
+		{name: "env-without-braces", path: "~/$EXPAND_PATH_FOLDER", expected: filepath.Join(home, "projects")},
+		{name: "absolute", path: "/var/app/../app", expected: "/var/app/../app"},
+		{name: "relative", path: "./app/../src", expected: filepath.Join(wd, "src")},
+		{name: "other-user", path: "~cindy/app", expectErr: true},
+		{name: "undefined-env", path: "~/$EXPAND_PATH_UNDEFINED", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPath(tt.path)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_expandLocalPath(t *testing.T) {
+	home := filepath.Join(os.TempDir(), "home")
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+	defer func() {
+		userHomeDir = os.UserHomeDir
+	}()
+
+	got, err := expandLocalPath("sync", "~/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Join(home, "app"); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	got, err = expandLocalPath("sync", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "app" {
+		t.Errorf("relative paths must be kept, got %s", got)
+	}
+
+	if _, err := expandLocalPath("sync", "~cindy/app"); err == nil || !strings.HasPrefix(err.Error(), "'sync'") {
+		t.Errorf("expected a 'sync' error, got %v", err)
+	}
+}
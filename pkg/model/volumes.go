@@ -15,11 +15,13 @@ package model
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func (dev *Dev) translateDeprecatedVolumeFields() error {
@@ -85,6 +87,18 @@ func (dev *Dev) translateDeprecatedWorkdir(main *Dev) error {
 	return nil
 }
 
+// setSyncRemotePathDefaults synchronizes the sync folders that only define a local path to 'workdir'
+func (dev *Dev) setSyncRemotePathDefaults() {
+	if dev.WorkDir == "" {
+		return
+	}
+	for i := range dev.Sync.Folders {
+		if dev.Sync.Folders[i].RemotePath == "" {
+			dev.Sync.Folders[i].RemotePath = dev.WorkDir
+		}
+	}
+}
+
 func (dev *Dev) translateDeprecatedVolumes() {
 	volumes := []Volume{}
 	for _, v := range dev.Volumes {
@@ -97,6 +111,21 @@ func (dev *Dev) translateDeprecatedVolumes() {
 	dev.Volumes = volumes
 }
 
+func (dev *Dev) removeMissingSyncFolders() {
+	folders := []SyncFolder{}
+	for _, f := range dev.Sync.Folders {
+		if !FileExists(f.LocalPath) {
+			log.Yellow("Sync folder '%s' can't be resolved from the current folder, skipping it", f.LocalPath)
+			continue
+		}
+		folders = append(folders, f)
+	}
+	dev.Sync.Folders = folders
+	for _, s := range dev.Services {
+		s.removeMissingSyncFolders()
+	}
+}
+
 //IsSubPathFolder checks if a sync folder is a subpath of another sync folder
 func (dev *Dev) IsSubPathFolder(path string) (bool, error) {
 	found := false
@@ -217,18 +246,21 @@ func (dev *Dev) validatePersistentVolume() error {
 func (dev *Dev) validateRemotePaths() error {
 	for _, v := range dev.Volumes {
 		if !strings.HasPrefix(v.RemotePath, "/") {
-			return fmt.Errorf("relative remote paths are not supported in the field 'volumes'")
+			return fmt.Errorf("volumes: remote path '%s' must be absolute", v.RemotePath)
 		}
 		if v.RemotePath == "/" {
-			return fmt.Errorf("remote path '/' is not supported in the field 'volumes'")
+			return fmt.Errorf("volumes: remote path '/' is not supported")
 		}
 	}
 	for _, sync := range dev.Sync.Folders {
+		if sync.RemotePath == "" {
+			return fmt.Errorf("sync: '%s' doesn't define a remote path. Use the syntax 'localPath:remotePath' or define 'workdir'", sync.LocalPath)
+		}
 		if !strings.HasPrefix(sync.RemotePath, "/") {
-			return fmt.Errorf("relative remote paths are not supported in the field 'sync'")
+			return fmt.Errorf("sync: remote path '%s' must be absolute", sync.RemotePath)
 		}
 		if sync.RemotePath == "/" {
-			return fmt.Errorf("remote path '/' is not supported in the field 'sync'")
+			return fmt.Errorf("sync: remote path '/' is not supported")
 		}
 	}
 	return nil
@@ -269,6 +301,50 @@ func (dev *Dev) validateDuplicatedSyncFolders() error {
 	return nil
 }
 
+func (dev *Dev) validateOverlappedSyncFolders() error {
+	for i, a := range dev.Sync.Folders {
+		for _, b := range dev.Sync.Folders[i+1:] {
+			if filepath.Clean(a.LocalPath) == filepath.Clean(b.LocalPath) {
+				return fmt.Errorf("sync: local path '%s' is synchronized to both '%s' and '%s'", a.LocalPath, a.RemotePath, b.RemotePath)
+			}
+			if localPathsOverlap(a.LocalPath, b.LocalPath) {
+				return fmt.Errorf("sync: local paths '%s' and '%s' overlap", a.LocalPath, b.LocalPath)
+			}
+			if remotePathsOverlap(a.RemotePath, b.RemotePath) {
+				return fmt.Errorf("sync: remote paths '%s' and '%s' overlap", a.RemotePath, b.RemotePath)
+			}
+		}
+	}
+	return nil
+}
+
+func localPathsOverlap(a, b string) bool {
+	a = filepath.Clean(a)
+	b = filepath.Clean(b)
+	sep := string(filepath.Separator)
+	return a == b || strings.HasPrefix(a, b+sep) || strings.HasPrefix(b, a+sep)
+}
+
+func remotePathsOverlap(a, b string) bool {
+	a = path.Clean(a)
+	b = path.Clean(b)
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+func (dev *Dev) validateSyncFoldersExist() error {
+	for _, sync := range dev.Sync.Folders {
+		if !FileExists(sync.LocalPath) {
+			return fmt.Errorf("sync: local path '%s' not found", sync.LocalPath)
+		}
+	}
+	for _, s := range dev.Services {
+		if err := s.validateSyncFoldersExist(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateServiceSyncFolders(main *Dev) error {
 	for _, sync := range dev.Sync.Folders {
 		_, err := main.IsSubPathFolder(sync.LocalPath)
@@ -299,6 +375,10 @@ func (dev *Dev) validateVolumes(main *Dev) error {
 		return err
 	}
 
+	if err := dev.validateOverlappedSyncFolders(); err != nil {
+		return err
+	}
+
 	if main == nil {
 		return nil
 	}
@@ -308,12 +388,23 @@ func (dev *Dev) validateVolumes(main *Dev) error {
 
 func (dev *Dev) validateExternalVolumes() error {
 	for _, v := range dev.ExternalVolumes {
+		if errs := validation.IsDNS1123Subdomain(v.Name); len(errs) > 0 {
+			return fmt.Errorf("external volume '%s' is not a valid claim name: %s", v.Name, strings.Join(errs, ", "))
+		}
 		if !strings.HasPrefix(v.MountPath, "/") {
 			return fmt.Errorf("external volume '%s' mount path must be absolute", v.Name)
 		}
 		if v.MountPath == "/" {
 			return fmt.Errorf("external volume '%s' mount path '/' is not supported", v.Name)
 		}
+		if remotePathsOverlap(v.MountPath, OktetoSyncthingMountPath) {
+			return fmt.Errorf("external volume '%s' mount path '%s' overlaps with the okteto syncthing volume", v.Name, v.MountPath)
+		}
+		for _, sync := range dev.Sync.Folders {
+			if remotePathsOverlap(v.MountPath, sync.RemotePath) {
+				return fmt.Errorf("external volume '%s' mount path '%s' overlaps with the sync folder '%s'", v.Name, v.MountPath, sync.RemotePath)
+			}
+		}
 	}
 	return nil
 }
@@ -14,8 +14,12 @@
 package model
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -1249,6 +1253,118 @@ func Test_validateVolumes(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "nested-local-paths",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src/lib",
+							RemotePath: "/lib",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "local-paths-with-common-prefix",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src2",
+							RemotePath: "/lib",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicated-subfolder-local-path",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src/lib",
+							RemotePath: "/lib1",
+						},
+						{
+							LocalPath:  "/src/lib/",
+							RemotePath: "/lib2",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "same-remote-path",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src1",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src2",
+							RemotePath: "/app/",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested-remote-paths",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src1",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src2",
+							RemotePath: "/app/lib",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote-paths-with-common-prefix",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src1",
+							RemotePath: "/app",
+						},
+						{
+							LocalPath:  "/src2",
+							RemotePath: "/application",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "wrong-service-sync-folder",
 			dev: &Dev{
@@ -1298,3 +1414,85 @@ func Test_validateVolumes(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateRemotePaths(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dev     *Dev
+		wantErr string
+	}{
+		{
+			name: "ok",
+			dev: &Dev{
+				Volumes: []Volume{{RemotePath: "/remote"}},
+				Sync:    Sync{Folders: []SyncFolder{{LocalPath: "src", RemotePath: "/app"}}},
+			},
+		},
+		{
+			name:    "relative-volume",
+			dev:     &Dev{Volumes: []Volume{{RemotePath: "remote"}}},
+			wantErr: "volumes: remote path 'remote' must be absolute",
+		},
+		{
+			name:    "root-volume",
+			dev:     &Dev{Volumes: []Volume{{RemotePath: "/"}}},
+			wantErr: "volumes: remote path '/' is not supported",
+		},
+		{
+			name:    "relative-sync",
+			dev:     &Dev{Sync: Sync{Folders: []SyncFolder{{LocalPath: "src", RemotePath: "app"}}}},
+			wantErr: "sync: remote path 'app' must be absolute",
+		},
+		{
+			name:    "root-sync",
+			dev:     &Dev{Sync: Sync{Folders: []SyncFolder{{LocalPath: "src", RemotePath: "/"}}}},
+			wantErr: "sync: remote path '/' is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dev.validateRemotePaths()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error '%s', got nil", tt.wantErr)
+			}
+			if err.Error() != tt.wantErr {
+				t.Fatalf("expected error '%s', got '%s'", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func Test_validateSyncFoldersExist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dev := &Dev{
+		Sync: Sync{Folders: []SyncFolder{{LocalPath: dir, RemotePath: "/app"}}},
+		Services: []*Dev{
+			{Sync: Sync{Folders: []SyncFolder{{LocalPath: dir, RemotePath: "/app"}}}},
+		},
+	}
+	if err := dev.validateSyncFoldersExist(); err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	dev.Services[0].Sync.Folders[0].LocalPath = missing
+	err = dev.validateSyncFoldersExist()
+	if err == nil {
+		t.Fatal("didn't get an error for a missing local path")
+	}
+	if !strings.Contains(err.Error(), missing) {
+		t.Errorf("error doesn't name the missing local path: %s", err)
+	}
+}
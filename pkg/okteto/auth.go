@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -124,7 +123,7 @@ func saveAuthData(user *User, url string) error {
 		return fmt.Errorf("certificate decoding error: %w", err)
 	}
 
-	return ioutil.WriteFile(GetCertificatePath(), d, 0600)
+	return config.WriteFileAtomic(GetCertificatePath(), d, 0600)
 }
 
 func queryUser(ctx context.Context, client *graphql.Client, token string) (*q, error) {
@@ -343,16 +342,7 @@ func save(t *Token) error {
 		return fmt.Errorf("Failed to generate your auth token")
 	}
 
-	p := getTokenPath()
-
-	if _, err := os.Stat(p); err == nil {
-		err = os.Chmod(p, 0600)
-		if err != nil {
-			return fmt.Errorf("couldn't change token permissions: %s", err)
-		}
-	}
-
-	if err := ioutil.WriteFile(p, marshalled, 0600); err != nil {
+	if err := config.WriteFileAtomic(getTokenPath(), marshalled, 0600); err != nil {
 		return fmt.Errorf("couldn't save authentication token: %s", err)
 	}
 
@@ -21,10 +21,10 @@ import (
 	"strings"
 
 	"github.com/machinebox/graphql"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 
-	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -35,7 +35,7 @@ func getClient(oktetoURL string) (*graphql.Client, error) {
 		return nil, err
 	}
 
-	graphqlClient := graphql.NewClient(u)
+	graphqlClient := graphql.NewClient(u, graphql.WithHTTPClient(config.NewHTTPClient()))
 	return graphqlClient, nil
 }
 
@@ -107,43 +107,31 @@ func translateAPIErr(err error) error {
 
 //SetKubeConfig updates a kubeconfig file with okteto cluster credentials
 func SetKubeConfig(cred *Credential, kubeConfigPath, namespace, userName, clusterName string) error {
-	cfg, err := getOrCreateKubeConfig(kubeConfigPath)
-	if err != nil {
-		return err
-	}
+	cluster, user, context := getKubeConfigEntries(cred, namespace, userName, clusterName)
+	return config.MergeIntoKubeConfig(kubeConfigPath, clusterName, cluster, context, user, true)
+}
 
-	// create cluster
-	cluster, ok := cfg.Clusters[clusterName]
-	if !ok {
-		cluster = clientcmdapi.NewCluster()
-	}
+//SetStandaloneKubeConfig writes a kubeconfig file with only the okteto cluster credentials.
+//Unless force is true, it fails if the file has contexts that okteto doesn't manage
+func SetStandaloneKubeConfig(cred *Credential, kubeConfigPath, namespace, userName, clusterName string, force bool) error {
+	cluster, user, context := getKubeConfigEntries(cred, namespace, userName, clusterName)
+	return config.WriteStandaloneKubeConfig(kubeConfigPath, clusterName, cluster, context, user, force)
+}
 
+func getKubeConfigEntries(cred *Credential, namespace, userName, clusterName string) (*clientcmdapi.Cluster, *clientcmdapi.AuthInfo, *clientcmdapi.Context) {
+	cluster := clientcmdapi.NewCluster()
 	cluster.CertificateAuthorityData = []byte(cred.Certificate)
 	cluster.Server = cred.Server
-	cfg.Clusters[clusterName] = cluster
 
-	// create user
-	user, ok := cfg.AuthInfos[userName]
-	if !ok {
-		user = clientcmdapi.NewAuthInfo()
-	}
+	user := clientcmdapi.NewAuthInfo()
 	user.Token = cred.Token
-	cfg.AuthInfos[userName] = user
-
-	// create context
-	context, ok := cfg.Contexts[clusterName]
-	if !ok {
-		context = clientcmdapi.NewContext()
-	}
 
+	context := clientcmdapi.NewContext()
 	context.Cluster = clusterName
 	context.AuthInfo = userName
 	context.Namespace = namespace
-	cfg.Contexts[clusterName] = context
-
-	cfg.CurrentContext = clusterName
 
-	return clientcmd.WriteToFile(*cfg, kubeConfigPath)
+	return cluster, user, context
 }
 
 // InDevContainer returns true if running in an okteto dev container
@@ -154,21 +142,3 @@ func InDevContainer() bool {
 
 	return false
 }
-
-func getOrCreateKubeConfig(kubeConfigPath string) (*clientcmdapi.Config, error) {
-	var cfg *clientcmdapi.Config
-	_, err := os.Stat(kubeConfigPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			cfg = clientcmdapi.NewConfig()
-		} else {
-			return nil, err
-		}
-	} else {
-		cfg, err = clientcmd.LoadFromFile(kubeConfigPath)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return cfg, nil
-}
@@ -18,13 +18,14 @@ import (
 	"strings"
 
 	"github.com/heroku/docker-registry-client/registry"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log"
 )
 
 // NewRegistryClient creates a new Registry with the given URL and credentials, then Ping()s it
 // before returning it to verify that the registry is available.
 func NewRegistryClient(registryURL, username, password string) (*registry.Registry, error) {
-	transport := http.DefaultTransport
+	transport := config.WithUserAgent(config.NewHTTPTransport())
 	return newFromTransport(registryURL, username, password, transport)
 }
 
@@ -19,7 +19,6 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 
 	"github.com/okteto/okteto/pkg/config"
@@ -72,11 +71,11 @@ func generateKeys(public, private string, bitSize int) error {
 
 	privateKeyBytes := encodePrivateKeyToPEM(privateKey)
 
-	if err := ioutil.WriteFile(public, publicKeyBytes, 0600); err != nil {
+	if err := config.WriteFileAtomic(public, publicKeyBytes, 0600); err != nil {
 		return fmt.Errorf("failed to write public SSH key: %s", err)
 	}
 
-	if err := ioutil.WriteFile(private, privateKeyBytes, 0600); err != nil {
+	if err := config.WriteFileAtomic(private, privateKeyBytes, 0600); err != nil {
 		return fmt.Errorf("failed to write private SSH key: %s", err)
 	}
 
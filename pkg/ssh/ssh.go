@@ -28,46 +28,41 @@ func buildHostname(name string) string {
 
 // AddEntry adds an entry to the user's sshconfig
 func AddEntry(name, iface string, port int) error {
-	return add(getSSHConfigPath(), buildHostname(name), iface, port)
-}
-
-func add(path, name, iface string, port int) error {
-	cfg, err := getConfig(path)
-	if err != nil {
-		return err
-	}
-
-	_ = removeHost(cfg, name)
-
 	_, privateKey := getKeyPaths()
-
-	host := newHost([]string{name}, []string{"entry generated by okteto"})
-	host.params = []*param{
-		newParam(forwardAgentKeyword, []string{"yes"}, nil),
-		newParam(hostNameKeyword, []string{iface}, nil),
-		newParam(portKeyword, []string{strconv.Itoa(port)}, nil),
-		newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-		newParam(userKnownHostsFileKeyword, []string{"/dev/null"}, nil),
-		newParam(identityFile, []string{"\"" + privateKey + "\""}, nil),
-	}
-
-	cfg.hosts = append(cfg.hosts, host)
-	return save(cfg, path)
+	_, err := config.GetOrCreateSSHConfig(&config.SSHHost{
+		Host:     buildHostname(name),
+		HostName: iface,
+		Port:     port,
+		Options: []config.SSHOption{
+			{Keyword: forwardAgentKeyword, Value: "yes"},
+			{Keyword: strictHostKeyCheckingKeyword, Value: "no"},
+			{Keyword: userKnownHostsFileKeyword, Value: "/dev/null"},
+			{Keyword: identityFile, Value: "\"" + privateKey + "\""},
+		},
+	})
+
+	return err
 }
 
 // RemoveEntry removes the entry to the user's sshconfig if found
 func RemoveEntry(name string) error {
-	return remove(getSSHConfigPath(), buildHostname(name))
+	return config.RemoveSSHConfig(buildHostname(name))
 }
 
 // GetPort returns the corresponding SSH port for the dev env
 func GetPort(name string) (int, error) {
+	hostname := buildHostname(name)
+	port, err := config.GetSSHConfigPort(hostname)
+	if err != config.ErrSSHHostNotFound {
+		return port, err
+	}
+
+	// entries written by previous versions of okteto
 	cfg, err := getConfig(getSSHConfigPath())
 	if err != nil {
 		return 0, err
 	}
 
-	hostname := buildHostname(name)
 	i, found := findHost(cfg, hostname)
 	if !found {
 		return 0, fmt.Errorf("development container not found")
@@ -78,7 +73,7 @@ func GetPort(name string) (int, error) {
 		return 0, fmt.Errorf("port not found")
 	}
 
-	port, err := strconv.Atoi(param.value())
+	port, err = strconv.Atoi(param.value())
 	if err != nil {
 		return 0, fmt.Errorf("invalid port: %s", param.value())
 	}
@@ -86,29 +81,6 @@ func GetPort(name string) (int, error) {
 	return port, nil
 }
 
-func remove(path, name string) error {
-	cfg, err := getConfig(path)
-	if err != nil {
-		return err
-	}
-
-	if removeHost(cfg, name) {
-		return save(cfg, path)
-	}
-
-	return nil
-}
-
-func removeHost(cfg *sshConfig, name string) bool {
-	ix, ok := findHost(cfg, name)
-	if ok {
-		cfg.hosts = append(cfg.hosts[:ix], cfg.hosts[ix+1:]...)
-		return true
-	}
-
-	return false
-}
-
 func findHost(cfg *sshConfig, name string) (int, bool) {
 	for i, h := range cfg.hosts {
 		for _, hn := range h.hostnames {
@@ -147,14 +119,6 @@ func getConfig(path string) (*sshConfig, error) {
 	return cfg, nil
 }
 
-func save(cfg *sshConfig, path string) error {
-	if err := cfg.writeToFilepath(path); err != nil {
-		return fmt.Errorf("fail to update SSH config file %s: %w", path, err)
-	}
-
-	return nil
-}
-
 func getSSHConfigPath() string {
 	return filepath.Join(config.GetUserHomeDir(), ".ssh", "config")
 }
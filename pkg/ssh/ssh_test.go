@@ -20,59 +20,40 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/model"
 )
 
-func Test_addOnEmpty(t *testing.T) {
+func setOktetoHome(t *testing.T) func() {
 	dir, err := ioutil.TempDir("", "")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if err := os.RemoveAll(dir); err != nil {
-		t.Fatal(err)
-	}
-
-	defer os.RemoveAll(dir)
-
-	sshConfig := filepath.Join(dir, "config")
-
-	if err := add(sshConfig, "test.okteto", model.Localhost, 8080); err != nil {
+	if err := os.Setenv("OKTETO_HOME", dir); err != nil {
 		t.Fatal(err)
 	}
 
-	cfg, err := getConfig(sshConfig)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	h := cfg.getHost("test.okteto")
-	if h == nil {
-		t.Fatal("couldn't find test.okteto")
-	}
-
-	if err := remove(sshConfig, "test.okteto"); err != nil {
-		t.Fatal(err)
+	config.ResetUserHomeDir()
+	return func() {
+		os.Unsetenv("OKTETO_HOME")
+		config.ResetUserHomeDir()
+		os.RemoveAll(dir)
 	}
 }
-func Test_add(t *testing.T) {
-	dir, err := ioutil.TempDir("", "")
-	if err != nil {
-		t.Fatal(err)
-	}
 
-	defer os.RemoveAll(dir)
-	sshConfig := filepath.Join(dir, "config")
+func Test_add(t *testing.T) {
+	defer setOktetoHome(t)()
 
-	if err := add(sshConfig, "test.okteto", model.Localhost, 8080); err != nil {
+	if err := AddEntry("test", model.Localhost, 8080); err != nil {
 		t.Fatal(err)
 	}
 
-	if err := add(sshConfig, "test2.okteto", model.Localhost, 8081); err != nil {
+	if err := AddEntry("test2", model.Localhost, 8081); err != nil {
 		t.Fatal(err)
 	}
 
-	cfg, err := getConfig(sshConfig)
+	cfg, err := getConfig(getSSHConfigPath())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -86,158 +67,61 @@ func Test_add(t *testing.T) {
 		t.Fatal("couldn't find test2.okteto")
 	}
 
-	if err := remove(sshConfig, "test.okteto"); err != nil {
+	if h.getParam(strictHostKeyCheckingKeyword) == nil {
+		t.Fatal("test2.okteto doesn't have the StrictHostKeyChecking option")
+	}
+
+	if err := RemoveEntry("test"); err != nil {
 		t.Fatal(err)
 	}
 
-	cfg, err = getConfig(sshConfig)
+	cfg, err = getConfig(getSSHConfigPath())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	h = cfg.getHost("test.okteto")
-	if h != nil {
-		t.Fatal("didn't delete test2.okteto")
+	if cfg.getHost("test.okteto") != nil {
+		t.Fatal("didn't delete test.okteto")
+	}
+
+	if cfg.getHost("test2.okteto") == nil {
+		t.Fatal("deleted test2.okteto")
 	}
 }
 
-func Test_removeHost(t *testing.T) {
-	tests := []struct {
-		name string
-		cfg  *sshConfig
-		host string
-		want bool
-	}{
-		{
-			name: "empty",
-			cfg: &sshConfig{
-				hosts: []*host{},
-			},
-			want: false,
-		},
-		{
-			name: "single-found",
-			cfg: &sshConfig{
-				hosts: []*host{
-					{
-						hostnames: []string{"test.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-				},
-			},
-			host: "test.okteto",
-			want: true,
-		},
-		{
-			name: "single-not-found",
-			cfg: &sshConfig{
-				hosts: []*host{
-					{
-						hostnames: []string{"test.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-				},
-			},
-			host: "test2.okteto",
-			want: false,
-		},
-		{
-			name: "multiple-found",
-			cfg: &sshConfig{
-				hosts: []*host{
-					{
-						hostnames: []string{"test.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-					{
-						hostnames: []string{"test2.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-					{
-						hostnames: []string{"test3.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-				},
-			},
-			host: "test2.okteto",
-			want: true,
-		},
-		{
-			name: "multiple-not-found",
-			cfg: &sshConfig{
-				hosts: []*host{
-					{
-						hostnames: []string{"test.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-					{
-						hostnames: []string{"test2.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-					{
-						hostnames: []string{"test3.okteto"},
-						params: []*param{
-							newParam(hostNameKeyword, []string{model.Localhost}, nil),
-							newParam(portKeyword, []string{"8080"}, nil),
-							newParam(strictHostKeyCheckingKeyword, []string{"no"}, nil),
-						},
-					},
-				},
-			},
-			host: "test4.okteto",
-			want: false,
-		},
+func TestGetPortLegacyEntry(t *testing.T) {
+	defer setOktetoHome(t)()
+
+	path := getSSHConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := removeHost(tt.cfg, tt.host); got != tt.want {
-				t.Errorf("removeHost() = %v, want %v", got, tt.want)
-			}
-		})
+
+	legacy := "# entry generated by okteto\nHost test.okteto\n  HostName localhost\n  Port 8080\n  StrictHostKeyChecking no\n"
+	if err := ioutil.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
 	}
-}
 
-func TestGetPort(t *testing.T) {
-	dir, err := ioutil.TempDir("", "")
+	p, err := GetPort("test")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	defer os.RemoveAll(dir)
+	if p != 8080 {
+		t.Errorf("got %d, expected %d", p, 8080)
+	}
 
-	if err := os.Setenv("OKTETO_HOME", dir); err != nil {
+	if err := RemoveEntry("test"); err != nil {
 		t.Fatal(err)
 	}
 
-	defer os.Unsetenv("OKTETO_HOME")
+	if _, err := GetPort("test"); err == nil {
+		t.Fatal("the legacy entry wasn't removed")
+	}
+}
+
+func TestGetPort(t *testing.T) {
+	defer setOktetoHome(t)()
 
 	if _, err := GetPort(t.Name()); err == nil {
 		t.Fatal("expected error on non existing host")
@@ -0,0 +1,147 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// eventsPollTimeout is how long syncthing waits for new events before answering an events request
+const eventsPollTimeout = 10
+
+// ItemFinishedEvent is the event sent by syncthing when it finishes synchronizing a file
+type ItemFinishedEvent struct {
+	ID   int              `json:"id"`
+	Data ItemFinishedData `json:"data"`
+}
+
+// ItemFinishedData represents the data of an ItemFinished event
+type ItemFinishedData struct {
+	Item   string  `json:"item"`
+	Folder string  `json:"folder"`
+	Action string  `json:"action"`
+	Error  *string `json:"error"`
+}
+
+// WatchRemoteChanges sends to changes the paths, relative to their sync folder, of the files
+// that the synchronization updates in the development container. It returns when ctx is done
+func (s *Syncthing) WatchRemoteChanges(ctx context.Context, changes chan<- string) {
+	// the events are long polled, the client shared with the monitor uses shorter timeouts
+	client := NewAPIClient()
+	client.Timeout = (eventsPollTimeout + 15) * time.Second
+
+	since, err := s.lastItemFinishedEventID(ctx, client)
+	if err != nil {
+		log.Infof("failed to get the last syncthing event: %s", err)
+	}
+
+	for {
+		events, err := s.getItemFinishedEvents(ctx, client, since, eventsPollTimeout, 0)
+		if err != nil {
+			log.Infof("failed to get the syncthing events: %s", err)
+			select {
+			case <-time.After(5 * time.Second):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for _, e := range events {
+			if e.ID > since {
+				since = e.ID
+			}
+
+			if e.Data.Error != nil {
+				continue
+			}
+
+			select {
+			case changes <- e.Data.Item:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// lastItemFinishedEventID returns the id of the last ItemFinished event, so older changes are ignored
+func (s *Syncthing) lastItemFinishedEventID(ctx context.Context, client *http.Client) (int, error) {
+	events, err := s.getItemFinishedEvents(ctx, client, 0, 0, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	last := 0
+	for _, e := range events {
+		if e.ID > last {
+			last = e.ID
+		}
+	}
+
+	return last, nil
+}
+
+// getItemFinishedEvents returns the ItemFinished events after since. A limit of 0 returns all of them,
+// the events aren't requested through APICall because it limits them to the last 30
+func (s *Syncthing) getItemFinishedEvents(ctx context.Context, client *http.Client, since, timeout, limit int) ([]ItemFinishedEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%s/rest/events", s.RemoteGUIAddress), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize syncthing API request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("events", "ItemFinished")
+	q.Add("since", strconv.Itoa(since))
+	q.Add("timeout", strconv.Itoa(timeout))
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(limit))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call syncthing [rest/events]: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from syncthing [%s | %d]", req.URL.String(), resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from syncthing [rest/events]: %w", err)
+	}
+
+	events := []ItemFinishedEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the syncthing events: %w", err)
+	}
+
+	return events, nil
+}
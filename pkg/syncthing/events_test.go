@@ -0,0 +1,63 @@
+// Copyright 2020 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchRemoteChanges(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/events" || r.URL.Query().Get("events") != "ItemFinished" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("limit") != "" && r.URL.Query().Get("since") != "0" {
+			t.Errorf("the events are limited: %s", r.URL.String())
+		}
+
+		switch r.URL.Query().Get("since") {
+		case "0":
+			fmt.Fprint(w, `[{"id": 3, "data": {"item": "older.go"}}]`)
+		case "3":
+			fmt.Fprint(w, `[{"id": 4, "data": {"item": "broken.go", "error": "permission denied"}}, {"id": 5, "data": {"item": "main.go"}}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	s := &Syncthing{RemoteGUIAddress: strings.TrimPrefix(ts.URL, "http://")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	go s.WatchRemoteChanges(ctx, changes)
+
+	select {
+	case c := <-changes:
+		if c != "main.go" {
+			t.Errorf("expected main.go, got %s", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change wasn't sent")
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	getter "github.com/hashicorp/go-getter"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -66,11 +67,16 @@ func Install(p getter.ProgressTracker) error {
 		return fmt.Errorf("failed to create temp download dir")
 	}
 
+	httpGetter := &getter.HttpGetter{Client: config.NewHTTPClient()}
 	client := &getter.Client{
 		Src:     downloadURL,
 		Dst:     dir,
 		Mode:    getter.ClientModeDir,
 		Options: opts,
+		Getters: map[string]getter.Getter{
+			"http":  httpGetter,
+			"https": httpGetter,
+		},
 	}
 
 	defer os.RemoveAll(dir)
@@ -102,6 +108,13 @@ func Install(p getter.ProgressTracker) error {
 	}
 
 	log.Infof("downloaded syncthing %s to %s", syncthingVersion, i)
+
+	if legacy := getLegacyInstallPath(); legacy != i && model.FileExists(legacy) {
+		if err := os.Remove(legacy); err != nil {
+			log.Infof("failed to delete %s: %s", legacy, err)
+		}
+	}
+
 	return nil
 }
 
@@ -252,11 +252,11 @@ func (s *Syncthing) initConfig() error {
 		return err
 	}
 
-	if err := ioutil.WriteFile(filepath.Join(s.Home, certFile), cert, 0700); err != nil {
+	if err := config.WriteFileAtomic(filepath.Join(s.Home, certFile), cert, 0700); err != nil {
 		return fmt.Errorf("failed to write syncthing certificate: %w", err)
 	}
 
-	if err := ioutil.WriteFile(filepath.Join(s.Home, keyFile), key, 0700); err != nil {
+	if err := config.WriteFileAtomic(filepath.Join(s.Home, keyFile), key, 0700); err != nil {
 		return fmt.Errorf("failed to write syncthing key: %w", err)
 	}
 
@@ -270,7 +270,7 @@ func (s *Syncthing) UpdateConfig() error {
 		return fmt.Errorf("failed to write syncthing configuration template: %w", err)
 	}
 
-	if err := ioutil.WriteFile(filepath.Join(s.Home, configFile), buf.Bytes(), 0700); err != nil {
+	if err := config.WriteFileAtomic(filepath.Join(s.Home, configFile), buf.Bytes(), 0700); err != nil {
 		return fmt.Errorf("failed to write syncthing configuration file: %w", err)
 	}
 
@@ -739,7 +739,7 @@ func (s *Syncthing) SaveConfig(dev *model.Dev) error {
 	}
 
 	syncthingInfoFile := getInfoFile(dev.Namespace, dev.Name)
-	if err := ioutil.WriteFile(syncthingInfoFile, marshalled, 0600); err != nil {
+	if err := config.WriteFileAtomic(syncthingInfoFile, marshalled, 0600); err != nil {
 		return fmt.Errorf("failed to write syncthing info file: %w", err)
 	}
 
@@ -835,6 +835,11 @@ func getPID(pidPath string) (int, error) {
 }
 
 func getInstallPath() string {
+	return filepath.Join(config.GetCacheDir(), getBinaryName())
+}
+
+// getLegacyInstallPath returns the path where syncthing was installed before the cache folder existed
+func getLegacyInstallPath() string {
 	return filepath.Join(config.GetOktetoHome(), getBinaryName())
 }
 